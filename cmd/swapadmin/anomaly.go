@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	anomalyCommand = &cli.Command{
+		Action:    anomaly,
+		Name:      "anomaly",
+		Usage:     "query or resume anomaly detector",
+		ArgsUsage: "<status|resume>",
+		Description: `
+query whether anomaly detector is tripped,
+or resume building swap tx of pairs paused by anomaly detector
+`,
+		Flags: commonAdminFlags,
+	}
+)
+
+func anomaly(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	method := "anomaly"
+	if ctx.NArg() != 1 {
+		_ = cli.ShowCommandHelp(ctx, method)
+		fmt.Println()
+		return fmt.Errorf("invalid arguments: %q", ctx.Args())
+	}
+
+	err := prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	operation := ctx.Args().Get(0)
+
+	switch operation {
+	case "status", "resume":
+	default:
+		return fmt.Errorf("unknown operation '%v'", operation)
+	}
+
+	log.Printf("admin anomaly: %v", operation)
+
+	params := []string{operation}
+	result, err := adminCall(method, params)
+
+	log.Printf("result is '%v'", result)
+	return err
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	healthcheckCommand = &cli.Command{
+		Action:    healthcheck,
+		Name:      "healthcheck",
+		Usage:     "admin check health of bridges",
+		ArgsUsage: " ",
+		Description: `
+diagnose problems of bridges, eg. txs of dcrm address sharing one nonce in txpool
+`,
+		Flags: commonAdminFlags,
+	}
+)
+
+func healthcheck(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	method := "healthcheck"
+	if ctx.NArg() != 0 {
+		_ = cli.ShowCommandHelp(ctx, method)
+		fmt.Println()
+		return fmt.Errorf("invalid arguments: %q", ctx.Args())
+	}
+
+	err := prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("admin healthcheck")
+
+	params := []string{}
+	result, err := adminCall(method, params)
+
+	log.Printf("result is '%v'", result)
+	return err
+}
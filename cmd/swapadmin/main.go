@@ -31,6 +31,11 @@ func initApp() {
 		manualCommand,
 		setnonceCommand,
 		addpairCommand,
+		anomalyCommand,
+		refundCommand,
+		healthcheckCommand,
+		sendstatsCommand,
+		txhistoryCommand,
 		utils.LicenseCommand,
 		utils.VersionCommand,
 	}
@@ -13,9 +13,10 @@ var (
 		Action:    maintain,
 		Name:      "maintain",
 		Usage:     "maintain deposit and withdraw switch",
-		ArgsUsage: "<open|close> <deposit|withdraw|both> <pairID>",
+		ArgsUsage: "<open|close> <deposit|withdraw|both|pair> <pairID>",
 		Description: `
-maintain service, open or close deposit and withdraw
+maintain service, open or close deposit and withdraw,
+or open or close building swap tx of the whole pair
 `,
 		Flags: commonAdminFlags,
 	}
@@ -46,7 +47,7 @@ func maintain(ctx *cli.Context) error {
 	}
 
 	switch direction {
-	case "deposit", "withdraw", "both":
+	case "deposit", "withdraw", "both", "pair":
 	default:
 		return fmt.Errorf("unknown direction '%v'", direction)
 	}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	refundCommand = &cli.Command{
+		Action:    refund,
+		Name:      "refund",
+		Usage:     "admin refund reverted swapout",
+		ArgsUsage: "<txid> <pairID> <bind>",
+		Description: `
+refund swapout whose swap tx is reverted on source chain,
+mint the burned value back to the swapout sender on destination chain
+`,
+		Flags: commonAdminFlags,
+	}
+)
+
+func refund(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	method := "refund"
+	if ctx.NArg() != 3 {
+		_ = cli.ShowCommandHelp(ctx, method)
+		fmt.Println()
+		return fmt.Errorf("invalid arguments: %q", ctx.Args())
+	}
+
+	err := prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	txid := ctx.Args().Get(0)
+	pairID := ctx.Args().Get(1)
+	bind := ctx.Args().Get(2)
+
+	log.Printf("admin refund: %v %v %v", txid, pairID, bind)
+
+	params := []string{txid, pairID, bind}
+	result, err := adminCall(method, params)
+
+	log.Printf("result is '%v'", result)
+	return err
+}
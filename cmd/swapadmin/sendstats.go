@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	sendstatsCommand = &cli.Command{
+		Action:    sendstats,
+		Name:      "sendstats",
+		Usage:     "admin get count of in-flight and queued sending txs",
+		ArgsUsage: " ",
+		Description: `
+get count of in-flight and queued sending txs (queued when 'MaxConcurrentSends' is reached)
+`,
+		Flags: commonAdminFlags,
+	}
+)
+
+func sendstats(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	method := "sendstats"
+	if ctx.NArg() != 0 {
+		_ = cli.ShowCommandHelp(ctx, method)
+		fmt.Println()
+		return fmt.Errorf("invalid arguments: %q", ctx.Args())
+	}
+
+	err := prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("admin sendstats")
+
+	params := []string{}
+	result, err := adminCall(method, params)
+
+	log.Printf("result is '%v'", result)
+	return err
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	txhistoryCommand = &cli.Command{
+		Action:    txhistory,
+		Name:      "txhistory",
+		Usage:     "admin list tx history of address",
+		ArgsUsage: "<src|dst> <address> <fromBlock> <toBlock>",
+		Description: `
+best effort list txs involving address in block range, eg. for reconciliation of new dcrm address.
+erc20 transfers are found by logs, native transfers by scanning blocks (internal txs are missing).
+`,
+		Flags: commonAdminFlags,
+	}
+)
+
+func txhistory(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	method := "txhistory"
+	if ctx.NArg() != 4 {
+		_ = cli.ShowCommandHelp(ctx, method)
+		fmt.Println()
+		return fmt.Errorf("invalid arguments: %q", ctx.Args())
+	}
+
+	err := prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	chain := ctx.Args().Get(0)
+	address := ctx.Args().Get(1)
+	fromBlock := ctx.Args().Get(2)
+	toBlock := ctx.Args().Get(3)
+
+	log.Printf("admin txhistory: %v %v %v %v", chain, address, fromBlock, toBlock)
+
+	params := []string{chain, address, fromBlock, toBlock}
+	result, err := adminCall(method, params)
+
+	log.Printf("result is '%v'", result)
+	return err
+}
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"sort"
+	"syscall"
 	"time"
 
 	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
@@ -24,6 +27,9 @@ var (
 	app = utils.NewApp(clientIdentifier, gitCommit, "the swapserver command line interface")
 )
 
+// max time to wait in-flight builds when shutdown
+const shutdownTimeout = 60 * time.Second
+
 func initApp() {
 	// Initialize the CLI app and start action
 	app.Action = swapserver
@@ -59,7 +65,6 @@ func swapserver(ctx *cli.Context) error {
 	if ctx.NArg() > 0 {
 		return fmt.Errorf("invalid command: %q", ctx.Args().Get(0))
 	}
-	exitCh := make(chan struct{})
 	configFile := utils.GetConfigFilePath(ctx)
 	config := params.LoadConfig(configFile, true)
 
@@ -72,6 +77,12 @@ func swapserver(ctx *cli.Context) error {
 	time.Sleep(100 * time.Millisecond)
 	rpcserver.StartAPIServer()
 
-	<-exitCh
-	return nil
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	sig := <-sigCh
+	log.Info("receive signal, shutdown swapserver", "signal", sig)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return worker.Shutdown(shutdownCtx)
 }
@@ -6,7 +6,6 @@ import (
 	"fmt"
 
 	"github.com/anyswap/CrossChain-Bridge/common"
-	"github.com/anyswap/CrossChain-Bridge/log"
 	"github.com/anyswap/CrossChain-Bridge/rpc/client"
 )
 
@@ -14,6 +13,12 @@ import (
 var (
 	ErrGetSignStatusTimeout = errors.New("getSignStatus timeout")
 	ErrGetSignStatusFailed  = errors.New("getSignStatus failure")
+
+	// ErrGetSignStatusExhausted sign status is still unavailable after all retries
+	ErrGetSignStatusExhausted = errors.New("get sign status failed")
+
+	// ErrRPCPostFailed post dcrm rpc request failed (eg. network error)
+	ErrRPCPostFailed = errors.New("dcrm rpc post failed")
 )
 
 const (
@@ -25,7 +30,7 @@ func newWrongStatusError(subject, status, errInfo string) error {
 }
 
 func wrapPostError(method string, err error) error {
-	return fmt.Errorf("[post] %v error, %v", method, err)
+	return fmt.Errorf("%w: [post] %v error, %v", ErrRPCPostFailed, method, err)
 }
 
 func httpPost(result interface{}, method string, params ...interface{}) error {
@@ -84,10 +89,10 @@ func GetSignStatus(key, rpcAddr string) (*SignStatus, error) {
 	}
 	switch signStatus.Status {
 	case "Failure":
-		log.Info("getSignStatus Failure", "keyID", key, "status", data)
+		logger.Info("getSignStatus Failure", "keyID", key, "status", signStatus.Status)
 		return nil, ErrGetSignStatusFailed
 	case "Timeout":
-		log.Info("getSignStatus Timeout", "keyID", key, "status", data)
+		logger.Info("getSignStatus Timeout", "keyID", key, "status", signStatus.Status)
 		return nil, ErrGetSignStatusTimeout
 	case successStatus:
 		return &signStatus, nil
@@ -0,0 +1,19 @@
+package dcrm
+
+import (
+	"github.com/anyswap/CrossChain-Bridge/log"
+)
+
+// logger of dcrm signing operations, tagged with 'component=dcrm'
+// and whose log level can be overridden by module 'dcrm'.
+// only log addresses, key ids and hashes, never log key material
+// (keystore, password, signature) or request internals (msg context, raw dcrm tx).
+var logger = NewLogger()
+
+// NewLogger new dcrm logger with extra context
+func NewLogger(ctx ...interface{}) *log.Logger {
+	fields := make([]interface{}, 0, len(ctx)+2)
+	fields = append(fields, "component", "dcrm")
+	fields = append(fields, ctx...)
+	return log.NewModuleLogger("dcrm", fields...)
+}
@@ -8,7 +8,6 @@ import (
 	"time"
 
 	"github.com/anyswap/CrossChain-Bridge/common"
-	"github.com/anyswap/CrossChain-Bridge/log"
 	"github.com/anyswap/CrossChain-Bridge/params"
 	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
 	"github.com/anyswap/CrossChain-Bridge/tools/keystore"
@@ -30,12 +29,12 @@ func getDcrmNode() *NodeInfo {
 			if err == nil {
 				return nodeInfo
 			}
-			log.Error("GetEnode of initiator failed", "rpcAddr", rpcAddr, "times", j+1, "err", err)
+			logger.Error("GetEnode of initiator failed", "rpcAddr", rpcAddr, "times", j+1, "err", err)
 			time.Sleep(1 * time.Second)
 		}
 		i = (i + 1) % countOfInitiators
 		if i == 0 {
-			log.Error("GetEnode of initiator failed all")
+			logger.Error("GetEnode of initiator failed all")
 			time.Sleep(60 * time.Second)
 		}
 	}
@@ -51,7 +50,7 @@ func DoSign(signPubkey string, msgHash, msgContext []string) (rpcAddr, result st
 	if !params.IsDcrmEnabled() {
 		return "", "", fmt.Errorf("dcrm sign is disabled")
 	}
-	log.Debug("dcrm DoSign", "msgHash", msgHash, "msgContext", msgContext)
+	logger.Debug("dcrm DoSign", "msgHash", msgHash)
 	if signPubkey == "" {
 		return "", "", fmt.Errorf("dcrm sign with empty public key")
 	}
@@ -34,6 +34,7 @@ func SetLogger(logLevel uint32, jsonFormat, colorFormat bool) {
 			DisableSorting:  false,
 		})
 	}
+	syncVerboseLogger()
 }
 
 // SetLogFile set log file path and rotation
@@ -64,6 +65,7 @@ func SetLogFile(logFile string, logRotation, logMaxAge uint64) {
 		logrus.Fatalf("Failed to Initialize Log File %s", err)
 	}
 	logrus.SetOutput(writer)
+	syncVerboseLogger()
 }
 
 // WithFields encapsulate logrus.WithFields
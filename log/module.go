@@ -0,0 +1,124 @@
+package log
+
+import (
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	moduleLevels     = make(map[string]logrus.Level)
+	moduleLevelsLock sync.RWMutex
+
+	// used when module log level is more verbose than the global one
+	verboseLogger = logrus.New()
+)
+
+// SetModuleLogLevel override log level of module (eg. 'build', 'pair:usdt')
+func SetModuleLogLevel(module string, logLevel uint32) {
+	moduleLevelsLock.Lock()
+	defer moduleLevelsLock.Unlock()
+	moduleLevels[module] = logrus.Level(logLevel)
+}
+
+// ClearModuleLogLevel remove log level override of module
+func ClearModuleLogLevel(module string) {
+	moduleLevelsLock.Lock()
+	defer moduleLevelsLock.Unlock()
+	delete(moduleLevels, module)
+}
+
+// get the most verbose log level override of modules
+func getModuleLogLevel(modules []string) (level logrus.Level, exist bool) {
+	moduleLevelsLock.RLock()
+	defer moduleLevelsLock.RUnlock()
+	for _, module := range modules {
+		if modLevel, ok := moduleLevels[module]; ok && (!exist || modLevel > level) {
+			level = modLevel
+			exist = true
+		}
+	}
+	return level, exist
+}
+
+func init() {
+	syncVerboseLogger()
+}
+
+func syncVerboseLogger() {
+	std := logrus.StandardLogger()
+	verboseLogger.SetOutput(std.Out)
+	verboseLogger.SetFormatter(std.Formatter)
+	verboseLogger.SetLevel(logrus.TraceLevel)
+}
+
+// Logger log with modules and context,
+// whose log level can be overridden by module log level
+type Logger struct {
+	modules []string
+	ctx     []interface{}
+}
+
+// NewModuleLogger new logger of module with context
+func NewModuleLogger(module string, ctx ...interface{}) *Logger {
+	return &Logger{
+		modules: []string{module},
+		ctx:     ctx,
+	}
+}
+
+// WithModule new logger with extra module
+func (l *Logger) WithModule(module string) *Logger {
+	modules := make([]string, 0, len(l.modules)+1)
+	modules = append(modules, l.modules...)
+	modules = append(modules, module)
+	return &Logger{modules: modules, ctx: l.ctx}
+}
+
+// With new logger with extra context
+func (l *Logger) With(ctx ...interface{}) *Logger {
+	newCtx := make([]interface{}, 0, len(l.ctx)+len(ctx))
+	newCtx = append(newCtx, l.ctx...)
+	newCtx = append(newCtx, ctx...)
+	return &Logger{modules: l.modules, ctx: newCtx}
+}
+
+func (l *Logger) log(level logrus.Level, msg string, ctx []interface{}) {
+	modLevel, exist := getModuleLogLevel(l.modules)
+	if exist && level > modLevel {
+		return
+	}
+	fields := make([]interface{}, 0, len(l.ctx)+len(ctx))
+	fields = append(fields, l.ctx...)
+	fields = append(fields, ctx...)
+	entry := WithFields(fields...)
+	if exist {
+		entry.Logger = verboseLogger
+	}
+	entry.Log(level, msg)
+}
+
+// Trace trace
+func (l *Logger) Trace(msg string, ctx ...interface{}) {
+	l.log(logrus.TraceLevel, msg, ctx)
+}
+
+// Debug debug
+func (l *Logger) Debug(msg string, ctx ...interface{}) {
+	l.log(logrus.DebugLevel, msg, ctx)
+}
+
+// Info info
+func (l *Logger) Info(msg string, ctx ...interface{}) {
+	l.log(logrus.InfoLevel, msg, ctx)
+}
+
+// Warn warn
+func (l *Logger) Warn(msg string, ctx ...interface{}) {
+	l.log(logrus.WarnLevel, msg, ctx)
+}
+
+// Error error
+func (l *Logger) Error(msg string, ctx ...interface{}) {
+	l.log(logrus.ErrorLevel, msg, ctx)
+}
@@ -183,7 +183,7 @@ func checkReswapNonce(bridge tokens.CrossChainBridge, res *MgoSwapResult, forceO
 	var nonce uint64
 	retryGetNonceCount := 3
 	for i := 0; i < retryGetNonceCount; i++ {
-		nonce, err = nonceSetter.GetPoolNonce(tokenCfg.DcrmAddress, "latest")
+		nonce, err = nonceSetter.GetNonce(tokenCfg.DcrmAddress, "latest")
 		if err == nil {
 			break
 		}
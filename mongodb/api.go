@@ -1,6 +1,7 @@
 package mongodb
 
 import (
+	"fmt"
 	"math/big"
 	"strings"
 	"sync"
@@ -39,6 +40,43 @@ func UpdateSwapResultStatus(isSwapin bool, txid, pairID, bind string, status Swa
 	return updateSwapResultStatus(collSwapoutResult, txid, pairID, bind, status, timestamp, memo)
 }
 
+// CompareAndSwapSwapResultStatus update swap result status only if its current status is 'oldStatus',
+// return ErrItemNotFound if the swap result does not exist or its status is changed
+func CompareAndSwapSwapResultStatus(isSwapin bool, txid, pairID, bind string, oldStatus, newStatus SwapStatus, timestamp int64, memo string) error {
+	collection := collSwapoutResult
+	if isSwapin {
+		collection = collSwapinResult
+	}
+	pairID = strings.ToLower(pairID)
+	updates := bson.M{"status": newStatus, "timestamp": timestamp}
+	if memo != "" {
+		updates["memo"] = memo
+	}
+	selector := bson.M{"_id": GetSwapKey(txid, pairID, bind), "status": oldStatus}
+	err := collection.Update(selector, bson.M{"$set": updates})
+	if err == nil {
+		log.Info("mongodb cas swap result status", "txid", txid, "pairID", pairID, "bind", bind, "old", oldStatus, "new", newStatus, "isSwapin", isSwapin)
+	} else {
+		log.Debug("mongodb cas swap result status", "txid", txid, "pairID", pairID, "bind", bind, "old", oldStatus, "new", newStatus, "isSwapin", isSwapin, "err", err)
+	}
+	return mgoError(err)
+}
+
+// MarkSwapFeeRecorded mark gas fee of swap tx is recorded,
+// return ErrItemNotFound if it's already marked (to record fee only once)
+func MarkSwapFeeRecorded(isSwapin bool, txid, pairID, bind, swapTx string) error {
+	collection := collSwapoutResult
+	if isSwapin {
+		collection = collSwapinResult
+	}
+	selector := bson.M{"_id": GetSwapKey(txid, pairID, bind), "feeswaptx": bson.M{"$ne": swapTx}}
+	err := collection.Update(selector, bson.M{"$set": bson.M{"feeswaptx": swapTx}})
+	if err != nil {
+		log.Debug("mongodb mark swap fee recorded", "txid", txid, "pairID", pairID, "bind", bind, "swaptx", swapTx, "isSwapin", isSwapin, "err", err)
+	}
+	return mgoError(err)
+}
+
 // FindSwapResult find swap result
 func FindSwapResult(isSwapin bool, txid, pairID, bind string) (*MgoSwapResult, error) {
 	if isSwapin {
@@ -497,6 +535,37 @@ func updateSwapStatistics(pairID, value, swapValue string, isSwapin bool) error
 	return mgoError(err)
 }
 
+// AddSwapGasFee add gas fee spent by swap tx to swap statistics
+func AddSwapGasFee(pairID string, fee *big.Int, isSwapin bool) error {
+	pairID = strings.ToLower(pairID)
+	curFee := big.NewInt(0)
+	var key string
+	if curr, err := FindSwapStatistics(pairID); err == nil {
+		if isSwapin {
+			curFee.SetString(curr.TotalSwapinGasFee, 0)
+		} else {
+			curFee.SetString(curr.TotalSwapoutGasFee, 0)
+		}
+	}
+	if isSwapin {
+		key = "totalswapingasfee"
+	} else {
+		key = "totalswapoutgasfee"
+	}
+	curFee.Add(curFee, fee)
+	updates := bson.M{
+		"pairid": pairID,
+		key:      curFee.String(),
+	}
+	_, err := collSwapStatistics.UpsertId(pairID, bson.M{"$set": updates})
+	if err == nil {
+		log.Info("mongodb add swap gas fee", "updates", updates)
+	} else {
+		log.Debug("mongodb add swap gas fee", "updates", updates, "err", err)
+	}
+	return mgoError(err)
+}
+
 // FindSwapStatistics find swap statistics
 func FindSwapStatistics(pairID string) (*MgoSwapStatistics, error) {
 	pairID = strings.ToLower(pairID)
@@ -518,6 +587,8 @@ type SwapStatistics struct {
 	StableSwapoutCount  int
 	TotalSwapoutValue   string
 	TotalSwapoutFee     string
+	TotalSwapinGasFee   string
+	TotalSwapoutGasFee  string
 }
 
 // GetSwapStatistics get swap statistics
@@ -532,6 +603,8 @@ func GetSwapStatistics(pairID string) (*SwapStatistics, error) {
 		stat.StableSwapoutCount = curr.StableSwapoutCount
 		stat.TotalSwapoutValue = curr.TotalSwapoutValue
 		stat.TotalSwapoutFee = curr.TotalSwapoutFee
+		stat.TotalSwapinGasFee = curr.TotalSwapinGasFee
+		stat.TotalSwapoutGasFee = curr.TotalSwapoutGasFee
 	}
 
 	stat.TotalSwapinCount, _ = GetCountOfSwapinResults(pairID)
@@ -655,3 +728,43 @@ func FindRegisteredAddress(key string) (*MgoRegisteredAddress, error) {
 	}
 	return &result, nil
 }
+
+// ------------------------ signed tx ------------------------------
+
+// GetSignedTxKey get signed tx key
+func GetSignedTxKey(swapType uint32, txid, pairID, bind string, nonce uint64) string {
+	return strings.ToLower(fmt.Sprintf("%d:%v:%v:%v:%d", swapType, txid, pairID, bind, nonce))
+}
+
+// AddSignedTx add signed tx (ignore if already exist)
+func AddSignedTx(ms *MgoSignedTx) error {
+	ms.Key = GetSignedTxKey(ms.SwapType, ms.TxID, ms.PairID, ms.Bind, ms.SwapNonce)
+	err := collSignedTx.Insert(ms)
+	if err == nil {
+		log.Info("mongodb add signed tx", "key", ms.Key, "swaptx", ms.SwapTx)
+	} else {
+		log.Debug("mongodb add signed tx", "key", ms.Key, "swaptx", ms.SwapTx, "err", err)
+	}
+	return mgoError(err)
+}
+
+// FindSignedTxs find signed txs
+func FindSignedTxs() ([]*MgoSignedTx, error) {
+	var result []*MgoSignedTx
+	err := collSignedTx.Find(nil).Sort("timestamp").All(&result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
+
+// RemoveSignedTx remove signed tx
+func RemoveSignedTx(key string) error {
+	err := collSignedTx.RemoveId(key)
+	if err == nil {
+		log.Info("mongodb remove signed tx", "key", key)
+	} else {
+		log.Debug("mongodb remove signed tx", "key", key, "err", err)
+	}
+	return mgoError(err)
+}
@@ -32,6 +32,7 @@ import (
 // TxSenderNotRegistered ---> MatchTxEmpty
 // MatchTxEmpty          -> | MatchTxNotStable -> |- MatchTxStable
 //                                                |- MatchTxFailed -> manual
+//                                                                   ---> SwapRefunding -> SwapRefunded (swapout only)
 // -----------------------------------------------
 
 // SwapStatus swap status
@@ -58,6 +59,8 @@ const (
 	ManualMakeFail                          // 16
 	BindAddrIsContract                      // 17
 	RPCQueryError                           // 18
+	SwapRefunded                            // 19
+	SwapRefunding                           // 20
 )
 
 // CanManualMakePass can manual make pass
@@ -160,6 +163,10 @@ func (status SwapStatus) String() string {
 		return "BindAddrIsContract"
 	case RPCQueryError:
 		return "RPCQueryError"
+	case SwapRefunded:
+		return "SwapRefunded"
+	case SwapRefunding:
+		return "SwapRefunding"
 	default:
 		return fmt.Sprintf("unknown swap status %d", status)
 	}
@@ -14,6 +14,7 @@ var (
 	collLatestScanInfo    *mgo.Collection
 	collRegisteredAddress *mgo.Collection
 	collBlacklist         *mgo.Collection
+	collSignedTx          *mgo.Collection
 )
 
 func isSwapin(collection *mgo.Collection) bool {
@@ -31,6 +32,7 @@ func deinintCollections() {
 	collLatestScanInfo = database.C(tbLatestScanInfo)
 	collRegisteredAddress = database.C(tbRegisteredAddress)
 	collBlacklist = database.C(tbBlacklist)
+	collSignedTx = database.C(tbSignedTxs)
 }
 
 func initCollections() {
@@ -43,6 +45,7 @@ func initCollections() {
 	initCollection(tbLatestScanInfo, &collLatestScanInfo)
 	initCollection(tbRegisteredAddress, &collRegisteredAddress)
 	initCollection(tbBlacklist, &collBlacklist)
+	initCollection(tbSignedTxs, &collSignedTx, "timestamp")
 
 	initDefaultValue()
 }
@@ -10,6 +10,7 @@ const (
 	tbLatestScanInfo    string = "LatestScanInfo"
 	tbRegisteredAddress string = "RegisteredAddress"
 	tbBlacklist         string = "Blacklist"
+	tbSignedTxs         string = "SignedTxs"
 
 	keyOfSrcLatestScanInfo string = "srclatest"
 	keyOfDstLatestScanInfo string = "dstlatest"
@@ -49,6 +50,7 @@ type MgoSwapResult struct {
 	Status     SwapStatus `bson:"status"`
 	Timestamp  int64      `bson:"timestamp"`
 	Memo       string     `bson:"memo"`
+	FeeSwapTx  string     `bson:"feeswaptx,omitempty"` // swap tx whose gas fee is recorded
 }
 
 // SwapResultUpdateItems swap update items
@@ -86,6 +88,8 @@ type MgoSwapStatistics struct {
 	StableSwapoutCount int    `bson:"swapoutcount"`
 	TotalSwapoutValue  string `bson:"totalswapoutvalue"`
 	TotalSwapoutFee    string `bson:"totalswapoutfee"`
+	TotalSwapinGasFee  string `bson:"totalswapingasfee"`
+	TotalSwapoutGasFee string `bson:"totalswapoutgasfee"`
 }
 
 // MgoLatestScanInfo latest scan info
@@ -102,3 +106,19 @@ type MgoBlackAccount struct {
 	PairID    string `bson:"pairid"`
 	Timestamp int64  `bson:"timestamp"`
 }
+
+// MgoSignedTx signed tx waiting for confirmation (for resubmitting)
+type MgoSignedTx struct {
+	Key       string `bson:"_id"` // swaptype + txid + pairid + bind + nonce
+	PairID    string `bson:"pairid"`
+	TxID      string `bson:"txid"`
+	Bind      string `bson:"bind"`
+	SwapType  uint32 `bson:"swaptype"`
+	SwapTx    string `bson:"swaptx"`
+	SwapNonce uint64 `bson:"swapnonce"`
+	From      string `bson:"from"`
+	RawTx     string `bson:"rawtx"`
+	Timestamp int64  `bson:"timestamp"`
+	Deadline  int64  `bson:"deadline,omitempty"` // unix time, do not resubmit after it
+	TxType    uint32 `bson:"txtype,omitempty"`
+}
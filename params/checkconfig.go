@@ -2,6 +2,8 @@ package params
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/anyswap/CrossChain-Bridge/log"
@@ -41,6 +43,25 @@ func CheckConfig(isServer bool) (err error) {
 	if err != nil {
 		return err
 	}
+	switch strings.ToLower(config.SwapOrderingPolicy) {
+	case "", "fifo", "value":
+	default:
+		return fmt.Errorf("unknown 'SwapOrderingPolicy' '%v'", config.SwapOrderingPolicy)
+	}
+	if config.AnomalyDetector != nil {
+		err = config.AnomalyDetector.CheckConfig()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckConfig check anomaly detector config
+func (c *AnomalyDetectorConfig) CheckConfig() error {
+	if c.MaxBalanceDropPercent < 0 || c.MaxBalanceDropPercent > 100 {
+		return errors.New("anomaly detector 'MaxBalanceDropPercent' must be in range [0, 100]")
+	}
 	return nil
 }
 
@@ -66,6 +87,14 @@ func checkChainAndGatewayConfig() (err error) {
 	if err != nil {
 		return err
 	}
+	err = config.SrcGateway.CheckConfig()
+	if err != nil {
+		return err
+	}
+	err = config.DestGateway.CheckConfig()
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
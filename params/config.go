@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	"github.com/anyswap/CrossChain-Bridge/common"
@@ -14,6 +15,9 @@ import (
 const (
 	defaultAPIPort      = 11556
 	defServerConfigFile = "config.toml"
+
+	defaultDcrmSignRetryCount    = 5
+	defaultDcrmSignRetryInterval = 1 * time.Second
 )
 
 var (
@@ -37,6 +41,38 @@ type ServerConfig struct {
 	Oracle      *OracleConfig          `toml:",omitempty" json:",omitempty"`
 	BtcExtra    *tokens.BtcExtraConfig `toml:",omitempty" json:",omitempty"`
 	Admins      []string               `toml:",omitempty" json:",omitempty"`
+
+	// only warn (instead of exit) if token pairs config is inconsistent
+	LaxConfigCheck bool `toml:",omitempty" json:",omitempty"`
+
+	// extra substrings of transient rpc errors which are worth retrying
+	TransientRPCErrors []string `toml:",omitempty" json:",omitempty"`
+
+	// order of processing found swaps: fifo (default) or value (larger value first)
+	SwapOrderingPolicy string `toml:",omitempty" json:",omitempty"`
+
+	// max in-flight sending txs across all pairs (unlimited if 0)
+	MaxConcurrentSends int `toml:",omitempty" json:",omitempty"`
+
+	// override log level of module (eg. 'build', 'pair:usdt')
+	ModuleLogLevels map[string]uint32 `toml:",omitempty" json:",omitempty"`
+
+	// alert if sent swap tx is pending longer than this seconds (no alert if 0)
+	MaxPendingAge uint64 `toml:",omitempty" json:",omitempty"`
+	// mark swap failed (for reswap) if its pending tx is dropped and the nonce is consumed by another tx
+	MarkDroppedTxFailed bool `toml:",omitempty" json:",omitempty"`
+
+	// pause swaps automatically on abnormal conditions (server only)
+	AnomalyDetector *AnomalyDetectorConfig `toml:",omitempty" json:",omitempty"`
+}
+
+// AnomalyDetectorConfig anomaly detector config (trip if any rule is broken)
+type AnomalyDetectorConfig struct {
+	CheckInterval         uint64   `toml:",omitempty" json:",omitempty"` // seconds, default to 60
+	MaxGasPrice           uint64   `toml:",omitempty" json:",omitempty"` // in wei, suggested gas price of eth-like chains
+	MaxBalanceDropPercent float64  `toml:",omitempty" json:",omitempty"` // dcrm address balance drop between two checks
+	MaxConsecutiveReverts uint64   `toml:",omitempty" json:",omitempty"` // consecutive failed swap txs
+	PausePairs            []string `toml:",omitempty" json:",omitempty"` // pairs to pause when tripped, default to all pairs
 }
 
 // DcrmConfig dcrm related config
@@ -49,6 +85,10 @@ type DcrmConfig struct {
 	Initiators    []string
 	DefaultNode   *DcrmNodeConfig
 	OtherNodes    []*DcrmNodeConfig
+
+	// retry transient dcrm signing errors (eg. timeout) with doubling interval
+	SignRetryCount    uint64 `toml:",omitempty" json:",omitempty"` // default to 5
+	SignRetryInterval uint64 `toml:",omitempty" json:",omitempty"` // seconds, default to 1
 }
 
 // DcrmNodeConfig dcrm node config
@@ -97,6 +137,22 @@ func IsDcrmEnabled() bool {
 	return !GetConfig().Dcrm.Disable
 }
 
+// GetDcrmSignRetryCount get max times of trying dcrm signing
+func GetDcrmSignRetryCount() int {
+	if count := GetConfig().Dcrm.SignRetryCount; count > 0 {
+		return int(count)
+	}
+	return defaultDcrmSignRetryCount
+}
+
+// GetDcrmSignRetryInterval get base interval of retrying dcrm signing
+func GetDcrmSignRetryInterval() time.Duration {
+	if interval := GetConfig().Dcrm.SignRetryInterval; interval > 0 {
+		return time.Duration(interval) * time.Second
+	}
+	return defaultDcrmSignRetryInterval
+}
+
 // IsDcrmInitiator is initiator of dcrm sign
 func IsDcrmInitiator(account string) bool {
 	for _, initiator := range GetConfig().Dcrm.Initiators {
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"sync"
+)
+
+// default substrings of transient errors which are worth retrying
+var (
+	transientErrorPatterns = []string{
+		"timeout",
+		"connection refused",
+		"connection reset",
+		"broken pipe",
+		"rate limit",
+		"too many requests",
+		"header not found",
+		"status 429",
+		"status 502",
+		"status 503",
+		"status 504",
+	}
+	transientErrorPatternsLock sync.RWMutex
+)
+
+// AddTransientErrorPatterns add substrings of transient errors (case insensitive)
+func AddTransientErrorPatterns(patterns ...string) {
+	transientErrorPatternsLock.Lock()
+	defer transientErrorPatternsLock.Unlock()
+	for _, pattern := range patterns {
+		if pattern != "" {
+			transientErrorPatterns = append(transientErrorPatterns, strings.ToLower(pattern))
+		}
+	}
+}
+
+// IsTransientError is transient error which is worth retrying
+// (permanent errors like 'execution reverted' should return immediately)
+func IsTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+		return true
+	}
+	// connection closed by peer (decoding errors are not wrapped, so not matched here)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	errMsg := strings.ToLower(err.Error())
+	transientErrorPatternsLock.RLock()
+	defer transientErrorPatternsLock.RUnlock()
+	for _, pattern := range transientErrorPatterns {
+		if strings.Contains(errMsg, pattern) {
+			return true
+		}
+	}
+	return false
+}
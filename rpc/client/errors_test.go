@@ -0,0 +1,34 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"testing"
+)
+
+func TestIsTransientError(t *testing.T) {
+	var v interface{}
+	decodeErr := json.Unmarshal([]byte(`{"result":`), &v)
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{&url.Error{Op: "Post", URL: "http://127.0.0.1", Err: io.EOF}, true},
+		{fmt.Errorf("read body error: %w", io.ErrUnexpectedEOF), true},
+		{errors.New("dial tcp: connection refused"), true},
+		{errors.New("wrong response status 503. message: "), true},
+		{fmt.Errorf("unmarshal result error: %v", decodeErr), false},
+		{errors.New("unmarshal body error, body is \"\" err=\"unexpected EOF\""), false},
+		{errors.New("invalid rpc response of eth_getBlockByNumber: empty result"), false},
+		{errors.New("execution reverted"), false},
+		{nil, false},
+	}
+	for _, c := range cases {
+		if got := IsTransientError(c.err); got != c.want {
+			t.Errorf("IsTransientError(%v): want %v, got %v", c.err, c.want, got)
+		}
+	}
+}
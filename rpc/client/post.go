@@ -6,6 +6,8 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"sync"
+	"time"
 )
 
 const (
@@ -78,8 +80,81 @@ type jsonrpcResponse struct {
 	Result  json.RawMessage `json:"result,omitempty"`
 }
 
+// OnRPCErrorFunc hook func called on each rpc failure
+type OnRPCErrorFunc func(method, url string, err error)
+
+var (
+	onRPCErrorHooks     []OnRPCErrorFunc
+	onRPCErrorHooksLock sync.RWMutex
+)
+
+// RegisterOnRPCError register hook called on each rpc failure
+// (hooks are only for observing, the returned error is not altered)
+func RegisterOnRPCError(hook OnRPCErrorFunc) {
+	onRPCErrorHooksLock.Lock()
+	defer onRPCErrorHooksLock.Unlock()
+	onRPCErrorHooks = append(onRPCErrorHooks, hook)
+}
+
+func callOnRPCErrorHooks(method, url string, err error) {
+	onRPCErrorHooksLock.RLock()
+	defer onRPCErrorHooksLock.RUnlock()
+	for _, hook := range onRPCErrorHooks {
+		hook(method, url, err)
+	}
+}
+
+// ResponseValidator validate the raw result of successful rpc response
+type ResponseValidator func(result json.RawMessage) error
+
+var (
+	responseValidators     = make(map[string]ResponseValidator) // key is method
+	responseValidatorsLock sync.RWMutex
+)
+
+// RegisterResponseValidator register validator of rpc method
+// (validation failure is treated as permanent rpc error to trigger failover, not retry)
+func RegisterResponseValidator(method string, validator ResponseValidator) {
+	responseValidatorsLock.Lock()
+	defer responseValidatorsLock.Unlock()
+	if validator == nil {
+		delete(responseValidators, method)
+		return
+	}
+	responseValidators[method] = validator
+}
+
+func validateResponse(method string, result json.RawMessage) error {
+	responseValidatorsLock.RLock()
+	validator, exist := responseValidators[method]
+	responseValidatorsLock.RUnlock()
+	if !exist {
+		return nil
+	}
+	if err := validator(result); err != nil {
+		return fmt.Errorf("invalid rpc response of %v: %v", method, err)
+	}
+	return nil
+}
+
+// NotEmptyResultValidator reject empty or null result
+func NotEmptyResultValidator(result json.RawMessage) error {
+	switch string(result) {
+	case "", "null", `""`, `"0x"`:
+		return fmt.Errorf("empty result")
+	}
+	return nil
+}
+
 // RPCPostRequest rpc post request
-func RPCPostRequest(url string, req *Request, result interface{}) error {
+func RPCPostRequest(url string, req *Request, result interface{}) (err error) {
+	start := time.Now()
+	defer func() {
+		recordRPCCall(req.Method, time.Since(start), err)
+		if err != nil {
+			callOnRPCErrorHooks(req.Method, url, err)
+		}
+	}()
 	reqBody := &RequestBody{
 		Version: "2.0",
 		Method:  req.Method,
@@ -90,15 +165,15 @@ func RPCPostRequest(url string, req *Request, result interface{}) error {
 	if err != nil {
 		return err
 	}
-	return getResultFromJSONResponse(result, resp)
+	return getResultFromJSONResponse(req.Method, result, resp)
 }
 
-func getResultFromJSONResponse(result interface{}, resp *http.Response) error {
+func getResultFromJSONResponse(method string, result interface{}, resp *http.Response) error {
 	defer resp.Body.Close()
 	const maxReadContentLength int64 = 1024 * 1024 * 10 // 10M
 	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxReadContentLength))
 	if err != nil {
-		return fmt.Errorf("read body error: %v", err)
+		return fmt.Errorf("read body error: %w", err)
 	}
 	if resp.StatusCode != 200 {
 		return fmt.Errorf("wrong response status %v. message: %v", resp.StatusCode, string(body))
@@ -115,6 +190,9 @@ func getResultFromJSONResponse(result interface{}, resp *http.Response) error {
 	if jsonResp.Error != nil {
 		return fmt.Errorf("return error:  %v", jsonResp.Error.Error())
 	}
+	if err = validateResponse(method, jsonResp.Result); err != nil {
+		return err
+	}
 	err = json.Unmarshal(jsonResp.Result, &result)
 	if err != nil {
 		return fmt.Errorf("unmarshal result error: %v", err)
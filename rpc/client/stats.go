@@ -0,0 +1,96 @@
+package client
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// keep latest latency samples of each method to calc percentiles
+const maxLatencySamples = 1000
+
+// RPCMethodStats rpc call statistics of method
+type RPCMethodStats struct {
+	Method     string
+	Calls      uint64
+	Errors     uint64
+	LatencyP50 time.Duration
+	LatencyP99 time.Duration
+}
+
+type rpcMethodRecord struct {
+	calls     uint64
+	errors    uint64
+	latencies []time.Duration // ring buffer
+	next      int
+}
+
+var (
+	rpcStats     = make(map[string]*rpcMethodRecord)
+	rpcStatsLock sync.Mutex
+)
+
+func recordRPCCall(method string, latency time.Duration, err error) {
+	rpcStatsLock.Lock()
+	defer rpcStatsLock.Unlock()
+	record, exist := rpcStats[method]
+	if !exist {
+		record = &rpcMethodRecord{}
+		rpcStats[method] = record
+	}
+	record.calls++
+	if err != nil {
+		record.errors++
+	}
+	if len(record.latencies) < maxLatencySamples {
+		record.latencies = append(record.latencies, latency)
+	} else {
+		record.latencies[record.next] = latency
+		record.next = (record.next + 1) % maxLatencySamples
+	}
+}
+
+// RPCStats get rpc call statistics of all methods (sorted by calls descending)
+func RPCStats() []*RPCMethodStats {
+	rpcStatsLock.Lock()
+	defer rpcStatsLock.Unlock()
+	result := make([]*RPCMethodStats, 0, len(rpcStats))
+	for method, record := range rpcStats {
+		latencies := make([]time.Duration, len(record.latencies))
+		copy(latencies, record.latencies)
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		result = append(result, &RPCMethodStats{
+			Method:     method,
+			Calls:      record.calls,
+			Errors:     record.errors,
+			LatencyP50: percentile(latencies, 50),
+			LatencyP99: percentile(latencies, 99),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Calls != result[j].Calls {
+			return result[i].Calls > result[j].Calls
+		}
+		return result[i].Method < result[j].Method
+	})
+	return result
+}
+
+// ResetRPCStats clear rpc call statistics
+func ResetRPCStats() {
+	rpcStatsLock.Lock()
+	defer rpcStatsLock.Unlock()
+	rpcStats = make(map[string]*rpcMethodRecord)
+}
+
+// percentile of sorted latencies (nearest rank)
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
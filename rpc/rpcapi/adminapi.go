@@ -1,8 +1,10 @@
 package rpcapi
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/anyswap/CrossChain-Bridge/admin"
 	"github.com/anyswap/CrossChain-Bridge/common"
@@ -60,6 +62,16 @@ func doCall(args *admin.CallArgs, result *string) error {
 		return setnonce(args, result)
 	case "addpair":
 		return addpair(args, result)
+	case "anomaly":
+		return anomaly(args, result)
+	case "refund":
+		return refund(args, result)
+	case "healthcheck":
+		return healthcheck(args, result)
+	case "sendstats":
+		return sendstats(args, result)
+	case "txhistory":
+		return txhistory(args, result)
 	default:
 		return fmt.Errorf("unknown admin method '%v'", args.Method)
 	}
@@ -144,6 +156,11 @@ func maintain(args *admin.CallArgs, result *string) (err error) {
 	isDeposit := false
 	isWithdraw := false
 	switch direction {
+	case "pair":
+		if !tokens.IsTokenPairExist(pairID) {
+			return fmt.Errorf("pairID %v is not configed", pairID)
+		}
+		tokens.SetPairDisabled(pairID, newDisableFlag)
 	case "deposit":
 		isDeposit = true
 	case "withdraw":
@@ -315,3 +332,101 @@ func addpair(args *admin.CallArgs, result *string) (err error) {
 	*result = successReuslt
 	return nil
 }
+
+func anomaly(args *admin.CallArgs, result *string) (err error) {
+	if len(args.Params) != 1 {
+		return fmt.Errorf("wrong number of params, have %v want 1", len(args.Params))
+	}
+	operation := args.Params[0]
+	switch operation {
+	case "status":
+		tripped, reason := worker.GetAnomalyStatus()
+		if tripped {
+			*result = "tripped: " + reason
+		} else {
+			*result = "normal"
+		}
+	case "resume":
+		worker.ResumeFromAnomaly()
+		*result = successReuslt
+	default:
+		return fmt.Errorf("unknown operation '%v'", operation)
+	}
+	return nil
+}
+
+func refund(args *admin.CallArgs, result *string) (err error) {
+	if len(args.Params) != 3 {
+		return fmt.Errorf("wrong number of params, have %v want 3", len(args.Params))
+	}
+	txid := args.Params[0]
+	pairID := args.Params[1]
+	bind := args.Params[2]
+	refundTx, err := worker.RefundSwapout(txid, pairID, bind)
+	if err != nil {
+		return err
+	}
+	*result = refundTx
+	return nil
+}
+
+func healthcheck(args *admin.CallArgs, result *string) (err error) {
+	if len(args.Params) != 0 {
+		return fmt.Errorf("wrong number of params, have %v want 0", len(args.Params))
+	}
+	problems := worker.CheckHealth()
+	if len(problems) == 0 {
+		*result = "healthy"
+	} else {
+		*result = strings.Join(problems, "; ")
+	}
+	return nil
+}
+
+func sendstats(args *admin.CallArgs, result *string) (err error) {
+	if len(args.Params) != 0 {
+		return fmt.Errorf("wrong number of params, have %v want 0", len(args.Params))
+	}
+	inFlight, queued := tokens.GetSendConcurrency()
+	*result = fmt.Sprintf("inFlight: %v, queued: %v", inFlight, queued)
+	return nil
+}
+
+func txhistory(args *admin.CallArgs, result *string) (err error) {
+	if len(args.Params) != 4 {
+		return fmt.Errorf("wrong number of params, have %v want 4", len(args.Params))
+	}
+	chain := args.Params[0]
+	address := args.Params[1]
+	fromBlock, err := common.GetUint64FromStr(args.Params[2])
+	if err != nil {
+		return fmt.Errorf("wrong from block, %v", err)
+	}
+	toBlock, err := common.GetUint64FromStr(args.Params[3])
+	if err != nil {
+		return fmt.Errorf("wrong to block, %v", err)
+	}
+	var bridge tokens.CrossChainBridge
+	switch chain {
+	case "src":
+		bridge = tokens.SrcBridge
+	case "dst":
+		bridge = tokens.DstBridge
+	default:
+		return fmt.Errorf("unknown chain '%v', should be src or dst", chain)
+	}
+	getter, ok := bridge.(tokens.AddressTxHistoryGetter)
+	if !ok {
+		return fmt.Errorf("address tx history not supported")
+	}
+	records, err := getter.GetAddressTxHistory(address, fromBlock, toBlock)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	*result = string(data)
+	return nil
+}
@@ -102,12 +102,90 @@ func ToBits(value float64, decimals uint8) *big.Int {
 	return result
 }
 
+// RoundingMode rounding direction of scaling down amount
+type RoundingMode string
+
+// rounding modes (rounding is applied to the absolute value)
+const (
+	RoundingFloor RoundingMode = "floor" // round toward zero
+	RoundingCeil  RoundingMode = "ceil"  // round away from zero
+	RoundingHalf  RoundingMode = "half"  // round half away from zero
+)
+
+// IsValid is valid rounding mode (empty is valid and means not configed)
+func (r RoundingMode) IsValid() bool {
+	switch r {
+	case "", RoundingFloor, RoundingCeil, RoundingHalf:
+		return true
+	default:
+		return false
+	}
+}
+
+// ConvertAmount convert value from 'fromDecimals' to 'toDecimals'.
+// Scaling up is exact, and scaling down is rounded toward zero,
+// so that the converted amount never exceeds the original one.
+func ConvertAmount(value *big.Int, fromDecimals, toDecimals uint8) *big.Int {
+	return ConvertAmountWithRounding(value, fromDecimals, toDecimals, RoundingFloor)
+}
+
+// ConvertAmountWithRounding convert value from 'fromDecimals' to 'toDecimals'.
+// Scaling up is exact, and scaling down is rounded by 'rounding' (default to floor).
+func ConvertAmountWithRounding(value *big.Int, fromDecimals, toDecimals uint8, rounding RoundingMode) *big.Int {
+	if value == nil {
+		return nil
+	}
+	switch {
+	case fromDecimals < toDecimals:
+		multiplier := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(toDecimals-fromDecimals)), nil)
+		return new(big.Int).Mul(value, multiplier)
+	case fromDecimals > toDecimals:
+		divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(fromDecimals-toDecimals)), nil)
+		quo, rem := new(big.Int).QuoRem(value, divisor, new(big.Int))
+		if rem.Sign() == 0 {
+			return quo
+		}
+		roundAway := false
+		switch rounding {
+		case RoundingCeil:
+			roundAway = true
+		case RoundingHalf:
+			roundAway = new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2)).Cmp(divisor) >= 0
+		}
+		if roundAway {
+			quo.Add(quo, big.NewInt(int64(value.Sign())))
+		}
+		return quo
+	default:
+		return new(big.Int).Set(value)
+	}
+}
+
 // GetBigValueThreshold get big value threshold
 func GetBigValueThreshold(pairID string, isSrc bool) *big.Int {
 	token := GetTokenConfig(pairID, isSrc)
 	return token.bigValThreshhold
 }
 
+// GetRequiredConfirmations get required confirmations of swap value by confirmation tiers,
+// return chain configed confirmations if no tier matches
+func GetRequiredConfirmations(pairID string, value *big.Int, isSrc bool) uint64 {
+	var required uint64
+	if chainCfg := GetCrossChainBridge(isSrc).GetChainConfig(); chainCfg.Confirmations != nil {
+		required = *chainCfg.Confirmations
+	}
+	token := GetTokenConfig(pairID, isSrc)
+	if token == nil || token.Decimals == nil || value == nil {
+		return required
+	}
+	for _, tier := range token.ConfirmationTiers {
+		if tier.Confirmations > required && value.Cmp(ToBits(tier.MinValue, *token.Decimals)) >= 0 {
+			required = tier.Confirmations
+		}
+	}
+	return required
+}
+
 // CheckSwapValue check swap value is in right range
 func CheckSwapValue(pairID string, value *big.Int, isSrc bool) bool {
 	token := GetTokenConfig(pairID, isSrc)
@@ -121,14 +199,17 @@ func CheckSwapValue(pairID string, value *big.Int, isSrc bool) bool {
 	return swappedValue.Sign() > 0
 }
 
-// CalcSwappedValue calc swapped value (get rid of fee)
+// CalcSwappedValue calc swapped value by the swap value calculator of pair,
+// the default one gets rid of fee, and converts it to the decimals of the token on the other side
+// (rounded by 'AmountRounding' of pair, no conversion if not configed)
 func CalcSwappedValue(pairID string, value *big.Int, isSrc bool) *big.Int {
-	token := GetTokenConfig(pairID, isSrc)
+	return GetSwapValueCalculator(pairID).CalcSwappedValue(pairID, value, isSrc)
+}
 
+func calcValueWithoutFee(token *TokenConfig, value *big.Int) *big.Int {
 	if *token.SwapFeeRate == 0.0 {
 		return value
 	}
-
 	swapValue := new(big.Float).SetInt(value)
 	swapFeeRate := new(big.Float).SetFloat64(*token.SwapFeeRate)
 	swapFeeFloat := new(big.Float).Mul(swapValue, swapFeeRate)
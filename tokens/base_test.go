@@ -0,0 +1,111 @@
+package tokens
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestConvertAmount(t *testing.T) {
+	cases := []struct {
+		value        string
+		fromDecimals uint8
+		toDecimals   uint8
+		want         string
+	}{
+		{"123456789", 8, 8, "123456789"},
+		{"123456789", 6, 18, "123456789000000000000"},
+		{"1", 0, 18, "1000000000000000000"},
+		{"123456789000000000000", 18, 6, "123456789"},
+		{"123456789999999999999", 18, 6, "123456789"}, // round down
+		{"999999999999", 18, 6, "0"},                  // less than one unit
+		{"0", 18, 6, "0"},
+		{"-1999999", 8, 2, "-1"}, // round toward zero
+	}
+	for _, c := range cases {
+		value, _ := new(big.Int).SetString(c.value, 10)
+		got := ConvertAmount(value, c.fromDecimals, c.toDecimals)
+		if got.String() != c.want {
+			t.Errorf("ConvertAmount(%v, %v, %v): want %v, got %v", c.value, c.fromDecimals, c.toDecimals, c.want, got)
+		}
+	}
+
+	value := big.NewInt(100)
+	_ = ConvertAmount(value, 2, 6)
+	if value.Int64() != 100 {
+		t.Errorf("ConvertAmount should not modify the input value")
+	}
+	if ConvertAmount(nil, 2, 6) != nil {
+		t.Errorf("ConvertAmount of nil should be nil")
+	}
+}
+
+func TestConvertAmountWithRounding(t *testing.T) {
+	cases := []struct {
+		value    string
+		rounding RoundingMode
+		want     string
+	}{
+		{"1500000", RoundingFloor, "1"},
+		{"1500000", RoundingCeil, "2"},
+		{"1500000", RoundingHalf, "2"},
+		{"1499999", RoundingHalf, "1"},
+		{"1000001", RoundingCeil, "2"},
+		{"1000001", RoundingFloor, "1"},
+		{"2000000", RoundingCeil, "2"}, // exact
+		{"1", RoundingCeil, "1"},
+		{"1", RoundingHalf, "0"},
+		{"-1500000", RoundingFloor, "-1"},
+		{"-1500000", RoundingCeil, "-2"},
+		{"1999999", "", "1"}, // default to floor
+	}
+	for _, c := range cases {
+		value, _ := new(big.Int).SetString(c.value, 10)
+		got := ConvertAmountWithRounding(value, 6, 0, c.rounding)
+		if got.String() != c.want {
+			t.Errorf("ConvertAmountWithRounding(%v, 6, 0, %q): want %v, got %v", c.value, c.rounding, c.want, got)
+		}
+	}
+	// scaling up is exact for all rounding modes
+	for _, rounding := range []RoundingMode{RoundingFloor, RoundingCeil, RoundingHalf} {
+		if got := ConvertAmountWithRounding(big.NewInt(15), 0, 6, rounding); got.String() != "15000000" {
+			t.Errorf("scale up with rounding %v: got %v", rounding, got)
+		}
+	}
+}
+
+func TestCalcSwappedValueWithDifferentDecimals(t *testing.T) {
+	srcDecimals, dstDecimals := uint8(6), uint8(18)
+	zeroFeeRate := 0.0
+	tokenPairsConfig = map[string]*TokenPairConfig{
+		"usdc": {
+			PairID:         "usdc",
+			SrcToken:       &TokenConfig{Decimals: &srcDecimals, SwapFeeRate: &zeroFeeRate},
+			DestToken:      &TokenConfig{Decimals: &dstDecimals, SwapFeeRate: &zeroFeeRate},
+			AmountRounding: RoundingFloor,
+		},
+	}
+	defer func() { tokenPairsConfig = nil }()
+
+	swapin := CalcSwappedValue("usdc", big.NewInt(1500000), true)
+	if swapin.String() != "1500000000000000000" {
+		t.Errorf("swapin should scale up, got %v", swapin)
+	}
+	value, _ := new(big.Int).SetString("1500000999999999999", 10)
+	swapout := CalcSwappedValue("usdc", value, false)
+	if swapout.String() != "1500000" {
+		t.Errorf("swapout should scale down and round down, got %v", swapout)
+	}
+
+	tokenPairsConfig["usdc"].AmountRounding = RoundingCeil
+	swapout = CalcSwappedValue("usdc", value, false)
+	if swapout.String() != "1500001" {
+		t.Errorf("swapout should scale down and round up, got %v", swapout)
+	}
+
+	// not configed rounding keeps the old behavior of no conversion
+	tokenPairsConfig["usdc"].AmountRounding = ""
+	swapout = CalcSwappedValue("usdc", value, false)
+	if swapout.Cmp(value) != 0 {
+		t.Errorf("swapout without rounding config should not convert, got %v", swapout)
+	}
+}
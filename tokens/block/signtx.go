@@ -256,7 +256,7 @@ func (b *Bridge) DcrmSignMsgHash(msgHash []string, args *tokens.BuildTxArgs) (rs
 		time.Sleep(retryGetSignStatusInterval)
 	}
 	if i == retryGetSignStatusCount || len(rsv) == 0 {
-		return nil, errors.New("get sign status failed")
+		return nil, dcrm.ErrGetSignStatusExhausted
 	}
 
 	rsv, err = b.adjustRsvOrders(rsv, msgHash, cfgFromPublicKey)
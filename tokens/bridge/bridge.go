@@ -7,6 +7,7 @@ import (
 	"github.com/anyswap/CrossChain-Bridge/dcrm"
 	"github.com/anyswap/CrossChain-Bridge/log"
 	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/rpc/client"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
 	"github.com/anyswap/CrossChain-Bridge/tokens/block"
 	"github.com/anyswap/CrossChain-Bridge/tokens/btc"
@@ -64,7 +65,13 @@ func InitCrossChainBridge(isServer bool) {
 	log.Info("Init bridge destation", "dest", dstID, "gateway", dstGateway)
 
 	tokens.IsDcrmDisabled = cfg.Dcrm.Disable
+	client.AddTransientErrorPatterns(cfg.TransientRPCErrors...)
+	tokens.SetMaxConcurrentSends(cfg.MaxConcurrentSends)
+	for module, logLevel := range cfg.ModuleLogLevels {
+		log.SetModuleLogLevel(module, logLevel)
+	}
 	tokens.LoadTokenPairsConfig(true)
+	validateAllTokenConfigs(cfg.LaxConfigCheck)
 
 	BlockChain := strings.ToUpper(srcChain.BlockChain)
 	switch BlockChain {
@@ -80,3 +87,17 @@ func InitCrossChainBridge(isServer bool) {
 
 	log.Info("Init bridge success", "isServer", isServer, "dcrmEnabled", !cfg.Dcrm.Disable)
 }
+
+func validateAllTokenConfigs(isLax bool) {
+	errs := tokens.ValidateAllTokenConfigs()
+	if len(errs) == 0 {
+		return
+	}
+	for _, err := range errs {
+		log.Error("validate token config failed", "err", err)
+	}
+	if !isLax {
+		log.Fatalf("validate token configs failed with %v errors", len(errs))
+	}
+	log.Warn("validate token configs failed in lax mode", "errors", len(errs))
+}
@@ -0,0 +1,73 @@
+package tokens
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+)
+
+// DefaultSwapValueCalculator name of the default swap value calculator
+const DefaultSwapValueCalculator = "Default"
+
+// SwapValueCalculator calc swapped value of pair (eg. tiered fees, rebasing tokens)
+type SwapValueCalculator interface {
+	CalcSwappedValue(pairID string, value *big.Int, isSrc bool) *big.Int
+}
+
+// defaultSwapValueCalculator deduct swap fee and convert decimals
+type defaultSwapValueCalculator struct{}
+
+func (defaultSwapValueCalculator) CalcSwappedValue(pairID string, value *big.Int, isSrc bool) *big.Int {
+	token := GetTokenConfig(pairID, isSrc)
+	swappedValue := calcValueWithoutFee(token, value)
+
+	pairCfg := GetTokenPairConfig(pairID)
+	if pairCfg == nil || pairCfg.AmountRounding == "" {
+		return swappedValue // keep amount unchanged if rounding is not configed
+	}
+	otherToken := GetTokenConfig(pairID, !isSrc)
+	if token.Decimals == nil || otherToken == nil || otherToken.Decimals == nil {
+		return swappedValue
+	}
+	return ConvertAmountWithRounding(swappedValue, *token.Decimals, *otherToken.Decimals, pairCfg.AmountRounding)
+}
+
+var (
+	swapValueCalculators     = make(map[string]SwapValueCalculator) // key is lower case name
+	swapValueCalculatorsLock sync.RWMutex
+)
+
+// RegisterSwapValueCalculator register swap value calculator (should be called before loading pairs config)
+func RegisterSwapValueCalculator(name string, calculator SwapValueCalculator) {
+	swapValueCalculatorsLock.Lock()
+	defer swapValueCalculatorsLock.Unlock()
+	swapValueCalculators[strings.ToLower(name)] = calculator
+}
+
+func getSwapValueCalculatorByName(name string) (SwapValueCalculator, error) {
+	if name == "" || strings.EqualFold(name, DefaultSwapValueCalculator) {
+		return defaultSwapValueCalculator{}, nil
+	}
+	swapValueCalculatorsLock.RLock()
+	defer swapValueCalculatorsLock.RUnlock()
+	calculator, exist := swapValueCalculators[strings.ToLower(name)]
+	if !exist || calculator == nil {
+		return nil, fmt.Errorf("unknown swap value calculator '%v'", name)
+	}
+	return calculator, nil
+}
+
+// GetSwapValueCalculator get swap value calculator of pair ('SwapValueCalculator' of pair config)
+func GetSwapValueCalculator(pairID string) SwapValueCalculator {
+	var name string
+	if pairCfg := GetTokenPairConfig(pairID); pairCfg != nil {
+		name = pairCfg.SwapValueCalculator
+	}
+	calculator, err := getSwapValueCalculatorByName(name)
+	if err != nil {
+		// pairs config is verified at load time, should not happen
+		return defaultSwapValueCalculator{}
+	}
+	return calculator
+}
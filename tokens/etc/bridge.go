@@ -82,7 +82,8 @@ func (b *Bridge) VerifyChainID() {
 		log.Fatalf("unsupported etc network %v", networkID)
 	}
 
-	b.Signer = types.MakeSigner("EIP155", chainID)
+	b.AssertExpectedChainID()
+	b.Signer = types.MakeSigner("London", chainID)
 
 	log.Info("VerifyChainID succeed", "networkID", networkID, "chainID", chainID)
 }
@@ -7,6 +7,32 @@ import (
 	"github.com/anyswap/CrossChain-Bridge/log"
 )
 
+// EncodeSwapinInput encode input of calling `Swapin(bytes32 txhash, address account, uint256 amount)`
+func EncodeSwapinInput(txhash common.Hash, account common.Address, amount *big.Int) []byte {
+	return PackDataWithFuncHash(swapinFuncHash, txhash, account, amount)
+}
+
+// EncodeErc20TransferInput encode input of calling `transfer(address to, uint256 amount)`
+func EncodeErc20TransferInput(to common.Address, amount *big.Int) []byte {
+	return PackDataWithFuncHash(erc20CodeParts["transfer"], to, amount)
+}
+
+// EncodeTransferAndCallInput encode input of calling ERC677 `transferAndCall(address to, uint256 amount, bytes data)`
+func EncodeTransferAndCallInput(to common.Address, amount *big.Int, data []byte) []byte {
+	return PackDataWithFuncHash(erc20CodeParts["transferAndCall"], to, amount, data)
+}
+
+// EncodeSafeTransferInput encode input of calling `safeTransfer(address token, address to, uint256 amount)`
+func EncodeSafeTransferInput(token, to common.Address, amount *big.Int) []byte {
+	return PackDataWithFuncHash(safeTransferFuncHash, token, to, amount)
+}
+
+// EncodeBatchTransferInput encode input of calling `batchTransfer(address token, address[] receivers, uint256[] amounts)`
+// (token is zero address for native coin)
+func EncodeBatchTransferInput(token common.Address, receivers []common.Address, amounts []*big.Int) []byte {
+	return PackDataWithFuncHash(batchTransferFuncHash, token, receivers, amounts)
+}
+
 // PackDataWithFuncHash pack data with func hash
 func PackDataWithFuncHash(funcHash []byte, args ...interface{}) []byte {
 	packData := PackData(args...)
@@ -35,12 +61,24 @@ func PackData(args ...interface{}) []byte {
 			offset := big.NewInt(int64(len(bs)))
 			copy(bs[i*32:], packBigInt(offset))
 			bs = append(bs, packString(v)...)
+		case []byte:
+			offset := big.NewInt(int64(len(bs)))
+			copy(bs[i*32:], packBigInt(offset))
+			bs = append(bs, packBytes(v)...)
 		case uint64:
 			copy(bs[i*32:], packBigInt(new(big.Int).SetUint64(v)))
 		case int64:
 			copy(bs[i*32:], packBigInt(big.NewInt(v)))
 		case int:
 			copy(bs[i*32:], packBigInt(big.NewInt(int64(v))))
+		case []common.Address:
+			offset := big.NewInt(int64(len(bs)))
+			copy(bs[i*32:], packBigInt(offset))
+			bs = append(bs, packAddressArray(v)...)
+		case []*big.Int:
+			offset := big.NewInt(int64(len(bs)))
+			copy(bs[i*32:], packBigInt(offset))
+			bs = append(bs, packBigIntArray(v)...)
 		default:
 			log.Fatalf("unsupported to pack %v (%T)", v, v)
 		}
@@ -56,6 +94,24 @@ func packAddress(address common.Address) []byte {
 	return address.Hash().Bytes()
 }
 
+func packAddressArray(addresses []common.Address) []byte {
+	bs := make([]byte, 32*(len(addresses)+1))
+	copy(bs[:32], packBigInt(big.NewInt(int64(len(addresses)))))
+	for i, address := range addresses {
+		copy(bs[32*(i+1):], packAddress(address))
+	}
+	return bs
+}
+
+func packBigIntArray(values []*big.Int) []byte {
+	bs := make([]byte, 32*(len(values)+1))
+	copy(bs[:32], packBigInt(big.NewInt(int64(len(values)))))
+	for i, value := range values {
+		copy(bs[32*(i+1):], packBigInt(value))
+	}
+	return bs
+}
+
 func packBigInt(bi *big.Int) []byte {
 	var bs []byte
 	if bi != nil {
@@ -64,6 +120,17 @@ func packBigInt(bi *big.Int) []byte {
 	return common.LeftPadBytes(bs, 32)
 }
 
+func packBool(b bool) []byte {
+	if b {
+		return packBigInt(big.NewInt(1))
+	}
+	return packBigInt(big.NewInt(0))
+}
+
+func packBytes(data []byte) []byte {
+	return packString(string(data))
+}
+
 func packString(str string) []byte {
 	strLen := len(str)
 	paddedStrLen := (strLen + 31) / 32 * 32
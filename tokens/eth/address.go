@@ -4,13 +4,23 @@ import (
 	"time"
 
 	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/rpc/client"
 )
 
 // IsValidAddress check address
 func (b *Bridge) IsValidAddress(address string) bool {
+	if b.isEnsEnabled() && IsEnsName(address) {
+		return true // resolve when building tx
+	}
 	if !common.IsHexAddress(address) {
 		return false
 	}
+	return IsValidChecksumAddress(address)
+}
+
+// IsValidChecksumAddress verify EIP-55 checksum if address has upper case char
+// (all lower case address is considered valid)
+func IsValidChecksumAddress(address string) bool {
 	unprefixedHex, ok, hasUpperChar := common.GetUnprefixedHex(address)
 	if hasUpperChar {
 		// valid checksum
@@ -30,6 +40,9 @@ func (b *Bridge) IsContractAddress(address string) (bool, error) {
 		if err == nil {
 			return len(code) != 0, nil
 		}
+		if !client.IsTransientError(err) {
+			break
+		}
 		time.Sleep(retryRPCInterval)
 	}
 	return false, err
@@ -0,0 +1,92 @@
+package eth
+
+import (
+	"math/big"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+// CheckAggregateBalance check whether the dcrm address of pair has enough
+// balance to send all the swapped amounts and pay gas fees of all these txs
+func (b *Bridge) CheckAggregateBalance(pairID string, amounts []*big.Int) error {
+	tokenCfg := b.GetTokenConfig(pairID)
+	if tokenCfg == nil {
+		return tokens.ErrUnknownPairID
+	}
+	if len(amounts) == 0 {
+		return nil
+	}
+	swapType := tokens.SwapinType
+	if b.IsSrc {
+		swapType = tokens.SwapoutType
+	}
+
+	totalAmount := big.NewInt(0)
+	for _, amount := range amounts {
+		if amount != nil && amount.Sign() > 0 {
+			totalAmount.Add(totalAmount, amount)
+		}
+	}
+
+	gasPrice, err := b.getGasPrice()
+	if err != nil {
+		return err
+	}
+	gasLimit := b.getDefaultGasLimit(pairID, swapType)
+	totalGasFee := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gasLimit))
+	totalGasFee.Mul(totalGasFee, big.NewInt(int64(len(amounts))))
+
+	from := tokenCfg.DcrmAddress
+	feeToken := tokenCfg.FeeToken
+
+	// query token balances of dcrm address at once
+	var contracts []string
+	if tokenCfg.IsErc20() {
+		contracts = append(contracts, tokenCfg.ContractAddress)
+	}
+	if feeToken != "" {
+		contracts = append(contracts, feeToken)
+	}
+	var tokenBalances []*big.Int
+	if len(contracts) > 0 {
+		tokenBalances, err = b.getErc20Balances(contracts, from)
+		if err != nil {
+			return err
+		}
+	}
+
+	needCoin := b.getMinReserveBalance(pairID, from)
+	if tokenCfg.IsErc20() {
+		tokenBalance := tokenBalances[0]
+		if tokenBalance.Cmp(totalAmount) < 0 {
+			log.Warn("not enough token balance for all swaps", "pairID", pairID, "count", len(amounts), "balance", tokenBalance, "need", totalAmount)
+			return tokens.ErrAggregateBalance
+		}
+	} else {
+		needCoin = new(big.Int).Add(needCoin, totalAmount)
+	}
+
+	if feeToken != "" {
+		feeBalance := tokenBalances[len(tokenBalances)-1]
+		if feeBalance.Cmp(totalGasFee) < 0 {
+			log.Warn("not enough fee token balance for all swaps", "pairID", pairID, "count", len(amounts), "balance", feeBalance, "need", totalGasFee)
+			return tokens.ErrAggregateBalance
+		}
+	} else {
+		needCoin = new(big.Int).Add(needCoin, totalGasFee)
+	}
+
+	if needCoin.Sign() == 0 {
+		return nil
+	}
+	balance, err := b.getCoinBalance(from)
+	if err != nil {
+		return err
+	}
+	if balance.Cmp(needCoin) < 0 {
+		log.Warn("not enough coin balance for all swaps", "pairID", pairID, "count", len(amounts), "balance", balance, "need", needCoin)
+		return tokens.ErrAggregateBalance
+	}
+	return nil
+}
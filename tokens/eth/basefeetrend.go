@@ -0,0 +1,57 @@
+package eth
+
+import (
+	"math/big"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+)
+
+const (
+	defaultBaseFeeTrendThreshold     = 10
+	defaultBaseFeeTrendMaxPercentage = 100
+)
+
+// getBaseFeeTrendPercentage get extra gas price percentage if base fee is rising sharply,
+// which is the rising percentage of the next block base fee to the oldest one in history.
+// return 0 if not enabled, not rising sharply, or fee history is unavailable.
+func (b *Bridge) getBaseFeeTrendPercentage() uint64 {
+	blocks := b.ChainConfig.BaseFeeTrendBlocks
+	if blocks == 0 || b.testStateProvider != nil {
+		return 0
+	}
+	feeHistory, err := b.FeeHistory(int(blocks), []float64{})
+	if err != nil {
+		log.Warn("get fee history for base fee trend failed", "err", err)
+		return 0
+	}
+	count := len(feeHistory.BaseFee)
+	if count < 2 || feeHistory.BaseFee[0] == nil || feeHistory.BaseFee[count-1] == nil {
+		return 0
+	}
+	oldest := feeHistory.BaseFee[0].ToInt()
+	next := feeHistory.BaseFee[count-1].ToInt()
+	if oldest.Sign() <= 0 || next.Cmp(oldest) <= 0 {
+		return 0
+	}
+	rise := new(big.Int).Sub(next, oldest)
+	rise.Mul(rise, big.NewInt(100))
+	rise.Div(rise, oldest)
+
+	threshold := b.ChainConfig.BaseFeeTrendThreshold
+	if threshold == 0 {
+		threshold = defaultBaseFeeTrendThreshold
+	}
+	if rise.Cmp(new(big.Int).SetUint64(threshold)) < 0 {
+		return 0
+	}
+	maxPercent := b.ChainConfig.BaseFeeTrendMaxPercentage
+	if maxPercent == 0 {
+		maxPercent = defaultBaseFeeTrendMaxPercentage
+	}
+	percent := maxPercent
+	if rise.IsUint64() && rise.Uint64() < maxPercent {
+		percent = rise.Uint64()
+	}
+	log.Info("base fee is rising, bump gas price", "oldest", oldest, "next", next, "blocks", blocks, "percent", percent)
+	return percent
+}
@@ -0,0 +1,134 @@
+package eth
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+// BuildBatchSwapoutTx build one tx for a batch of swapouts of one pair,
+// which calls 'batchTransfer' of the chain's 'BatchTransferHelper'
+func (b *Bridge) BuildBatchSwapoutTx(batch []*tokens.BuildTxArgs) (rawTx interface{}, err error) {
+	if len(batch) == 0 {
+		return nil, errors.New("empty batch swapout")
+	}
+	if !b.IsSrc {
+		return nil, tokens.ErrBuildSwapTxInWrongEndpoint
+	}
+	if b.IsWrongChain() {
+		return nil, tokens.ErrWrongChain
+	}
+	first := batch[0]
+	pairID := first.PairID
+	opts := &buildOptions{logger: newBuildLogger(pairID)}
+	tokenCfg := b.GetTokenConfig(pairID)
+	if tokenCfg == nil {
+		return nil, tokens.ErrUnknownPairID
+	}
+	if tokens.IsPairDisabled(pairID) {
+		return nil, tokens.ErrPairDisabled
+	}
+	if b.isBlobTx(pairID) {
+		return nil, errors.New("batch swapout with blob tx is not supported")
+	}
+	helper := b.ChainConfig.BatchTransferHelper
+	if helper == "" {
+		return nil, errors.New("batch swapout require 'BatchTransferHelper' in chain config")
+	}
+	if err = b.checkContractPaused(tokenCfg, opts); err != nil {
+		return nil, err
+	}
+
+	from := first.From
+	if from == "" {
+		from = tokenCfg.DcrmAddress
+	}
+	receivers := make([]common.Address, 0, len(batch))
+	amounts := make([]*big.Int, 0, len(batch))
+	for _, args := range batch {
+		if args.SwapType != tokens.SwapoutType || !strings.EqualFold(args.PairID, pairID) {
+			return nil, errors.New("batch swapout require swapouts of one pair")
+		}
+		if args.From != "" && !strings.EqualFold(args.From, from) {
+			return nil, errors.New("batch swapout require swapouts from one address")
+		}
+		receiver, amount, errf := b.getBatchSwapoutReceiver(tokenCfg, args, opts)
+		if errf != nil {
+			opts.logger.Warn("check batch swapout failed", "swapID", args.SwapID, "bind", args.Bind, "err", errf)
+			return nil, errf
+		}
+		receivers = append(receivers, receiver)
+		amounts = append(amounts, amount)
+	}
+
+	err = b.CheckAggregateBalance(pairID, amounts)
+	if err != nil {
+		return nil, err
+	}
+
+	var token common.Address
+	value := big.NewInt(0)
+	if tokenCfg.IsErc20() {
+		token = common.HexToAddress(tokenCfg.ContractAddress)
+	} else {
+		for _, amount := range amounts {
+			value.Add(value, amount)
+		}
+	}
+	input := EncodeBatchTransferInput(token, receivers, amounts)
+
+	// set defaults as swapout (gas price strategy and nonce adjusting of pair)
+	txArgs := &tokens.BuildTxArgs{
+		SwapInfo: tokens.SwapInfo{PairID: pairID, SwapType: tokens.SwapoutType},
+		From:     from,
+		To:       helper,
+		Value:    value,
+		Input:    &input,
+		Extra:    first.Extra,
+	}
+	hasGasLimit := first.Extra != nil && first.Extra.EthExtra != nil && first.Extra.EthExtra.Gas != nil
+	extra, err := b.setDefaults(txArgs)
+	if err != nil {
+		return nil, err
+	}
+	if !hasGasLimit {
+		*extra.Gas *= uint64(len(batch))
+	}
+
+	// build with the summed value and the batch input
+	txArgs.SwapType = tokens.NoSwapType
+	rawTx, err = b.buildTx(txArgs, extra, input, opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, args := range batch {
+		args.From = from
+		args.Extra = txArgs.Extra
+	}
+	return rawTx, nil
+}
+
+func (b *Bridge) getBatchSwapoutReceiver(tokenCfg *tokens.TokenConfig, args *tokens.BuildTxArgs, opts *buildOptions) (receiver common.Address, amount *big.Int, err error) {
+	receiver = common.HexToAddress(args.Bind)
+	if receiver == (common.Address{}) || !common.IsHexAddress(args.Bind) {
+		return receiver, nil, errors.New("can not swapout to empty or invalid address")
+	}
+	if tokenCfg.StrictBindChecksum && !IsValidChecksumAddress(args.Bind) {
+		return receiver, nil, tokens.ErrBindAddressChecksum
+	}
+	if err = b.checkRecipientBlacklisted(tokenCfg, args.Bind, opts); err != nil {
+		return receiver, nil, err
+	}
+	// origin value is of the other side
+	if err = tokens.CheckSwapUSDValue(tokenCfg, args.PairID, args.OriginValue, !b.IsSrc); err != nil {
+		return receiver, nil, err
+	}
+	amount = tokens.CalcSwappedValue(args.PairID, args.OriginValue, false)
+	if err = checkSwappedValue(tokenCfg, amount, opts); err != nil {
+		return receiver, nil, err
+	}
+	return receiver, amount, nil
+}
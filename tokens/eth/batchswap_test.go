@@ -0,0 +1,146 @@
+package eth
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/types"
+)
+
+const testBatchHelper = "0x5555555555555555555555555555555555555555"
+
+func newTestBatchBridge(t *testing.T, pairID, contract string) *Bridge {
+	zeroFeeRate := 0.0
+	decimals := uint8(18)
+	tokenID := ""
+	if contract != "" {
+		tokenID = ERC20TokenType
+	}
+	tokens.SetTokenPairsConfig(map[string]*tokens.TokenPairConfig{
+		pairID: {
+			PairID: pairID,
+			SrcToken: &tokens.TokenConfig{
+				ID:              tokenID,
+				ContractAddress: contract,
+				DcrmAddress:     testSwapDcrm,
+				SwapFeeRate:     &zeroFeeRate,
+				Decimals:        &decimals,
+				DefaultGasLimit: 50000,
+				BatchWindow:     10,
+			},
+			DestToken: &tokens.TokenConfig{DcrmAddress: testSwapDcrm, SwapFeeRate: &zeroFeeRate, Decimals: &decimals},
+		},
+	}, false)
+
+	b := NewCrossChainBridge(true)
+	b.ChainConfig = &tokens.ChainConfig{BlockChain: "ethereum", NetID: "test", BatchTransferHelper: testBatchHelper}
+	b.GatewayConfig = &tokens.GatewayConfig{}
+	b.Signer = types.MakeSigner("London", big.NewInt(1))
+	err := b.SetTestStateProvider(&FixedStateProvider{
+		GasPrice:     big.NewInt(1e9),
+		Nonce:        3,
+		Balance:      new(big.Int).Lsh(big.NewInt(1), 100),
+		TokenBalance: new(big.Int).Lsh(big.NewInt(1), 100),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func newTestBatchSwapout(pairID, bind string, value int64) *tokens.BuildTxArgs {
+	return &tokens.BuildTxArgs{
+		SwapInfo: tokens.SwapInfo{
+			PairID:   pairID,
+			SwapID:   common.BigToHash(big.NewInt(value)).String(),
+			SwapType: tokens.SwapoutType,
+			Bind:     bind,
+		},
+		From:        testSwapDcrm,
+		OriginValue: big.NewInt(value),
+	}
+}
+
+func TestBuildBatchSwapoutTx(t *testing.T) {
+	defer tokens.SetTokenPairsConfig(nil, false)
+
+	bind1 := "0x6666666666666666666666666666666666666666"
+	bind2 := "0x7777777777777777777777777777777777777777"
+
+	for _, contract := range []string{"", testSwapContract} {
+		pairID := "testbatch"
+		b := newTestBatchBridge(t, pairID, contract)
+		batch := []*tokens.BuildTxArgs{
+			newTestBatchSwapout(pairID, bind1, 1000),
+			newTestBatchSwapout(pairID, bind2, 2000),
+		}
+		rawTx, err := b.BuildBatchSwapoutTx(batch)
+		if err != nil {
+			t.Fatalf("build batch swapout (contract %q) failed: %v", contract, err)
+		}
+		tx := rawTx.(*types.Transaction)
+
+		token := common.HexToAddress(contract)
+		wantValue := big.NewInt(3000)
+		if contract != "" {
+			wantValue = big.NewInt(0)
+		}
+		wantInput := EncodeBatchTransferInput(token,
+			[]common.Address{common.HexToAddress(bind1), common.HexToAddress(bind2)},
+			[]*big.Int{big.NewInt(1000), big.NewInt(2000)})
+		if tx.To() == nil || *tx.To() != common.HexToAddress(testBatchHelper) {
+			t.Errorf("batch swapout (contract %q): want to %v, got %v", contract, testBatchHelper, tx.To())
+		}
+		if tx.Value().Cmp(wantValue) != 0 {
+			t.Errorf("batch swapout (contract %q): want value %v, got %v", contract, wantValue, tx.Value())
+		}
+		if !bytes.Equal(tx.Data(), wantInput) {
+			t.Errorf("batch swapout (contract %q): wrong input %x", contract, tx.Data())
+		}
+		if tx.Gas() != 100000 {
+			t.Errorf("batch swapout (contract %q): want gas limit %v, got %v", contract, 100000, tx.Gas())
+		}
+		for _, args := range batch {
+			if nonce := args.GetTxNonce(); nonce != tx.Nonce() {
+				t.Errorf("batch swapout (contract %q): want args nonce %v, got %v", contract, tx.Nonce(), nonce)
+			}
+		}
+	}
+}
+
+func TestBuildBatchSwapoutTxMixedPairs(t *testing.T) {
+	defer tokens.SetTokenPairsConfig(nil, false)
+
+	b := newTestBatchBridge(t, "testbatch", "")
+	batch := []*tokens.BuildTxArgs{
+		newTestBatchSwapout("testbatch", "0x6666666666666666666666666666666666666666", 1000),
+		newTestBatchSwapout("otherpair", "0x7777777777777777777777777777777777777777", 2000),
+	}
+	if _, err := b.BuildBatchSwapoutTx(batch); err == nil {
+		t.Errorf("build batch swapout of mixed pairs should fail")
+	}
+}
+
+func TestEncodeBatchTransferInput(t *testing.T) {
+	token := common.HexToAddress(testSwapContract)
+	receivers := []common.Address{common.HexToAddress("0x01"), common.HexToAddress("0x02")}
+	amounts := []*big.Int{big.NewInt(10), big.NewInt(20)}
+	input := EncodeBatchTransferInput(token, receivers, amounts)
+
+	want := common.FromHex("0x1239ec8c" +
+		"0000000000000000000000001111111111111111111111111111111111111111" + // token
+		"0000000000000000000000000000000000000000000000000000000000000060" + // offset of receivers
+		"00000000000000000000000000000000000000000000000000000000000000c0" + // offset of amounts
+		"0000000000000000000000000000000000000000000000000000000000000002" +
+		"0000000000000000000000000000000000000000000000000000000000000001" +
+		"0000000000000000000000000000000000000000000000000000000000000002" +
+		"0000000000000000000000000000000000000000000000000000000000000002" +
+		"000000000000000000000000000000000000000000000000000000000000000a" +
+		"0000000000000000000000000000000000000000000000000000000000000014")
+	if !bytes.Equal(input, want) {
+		t.Errorf("wrong batch transfer input %x", input)
+	}
+}
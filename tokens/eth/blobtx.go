@@ -0,0 +1,102 @@
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/types"
+)
+
+// BlobSidecarBuilder build blobs with kzg commitments and proofs for swap tx
+// (kzg is not implemented in this repo, so it is provided by the user)
+type BlobSidecarBuilder func(args *tokens.BuildTxArgs, input []byte) (*types.BlobTxSidecar, error)
+
+var (
+	blobSidecarBuilders     = make(map[string]BlobSidecarBuilder) // key is lower case name
+	blobSidecarBuildersLock sync.RWMutex
+)
+
+// RegisterBlobSidecarBuilder register blob sidecar builder (should be called before loading pairs config)
+func RegisterBlobSidecarBuilder(name string, builder BlobSidecarBuilder) {
+	blobSidecarBuildersLock.Lock()
+	defer blobSidecarBuildersLock.Unlock()
+	blobSidecarBuilders[strings.ToLower(name)] = builder
+}
+
+func getBlobSidecarBuilder(name string) (BlobSidecarBuilder, error) {
+	blobSidecarBuildersLock.RLock()
+	defer blobSidecarBuildersLock.RUnlock()
+	builder, exist := blobSidecarBuilders[strings.ToLower(name)]
+	if !exist || builder == nil {
+		return nil, fmt.Errorf("unknown blob sidecar builder '%v'", name)
+	}
+	return builder, nil
+}
+
+// isBlobTx build blob tx by the 'TxType' of pair
+func (b *Bridge) isBlobTx(pairID string) bool {
+	tokenCfg := b.GetTokenConfig(pairID)
+	return tokenCfg != nil && tokenCfg.TxType == tokens.TxTypeBlob
+}
+
+// verifyBlobTxType reject blob tx type on chain without blob support
+func (b *Bridge) verifyBlobTxType(tokenCfg *tokens.TokenConfig) error {
+	if _, ok := b.Signer.(types.LondonSigner); !ok {
+		return errors.New("'TxType' Blob require london signer")
+	}
+	if _, err := b.GetBlobBaseFee(); err != nil {
+		return fmt.Errorf("'TxType' Blob is not supported by chain: %v", err)
+	}
+	if _, err := getBlobSidecarBuilder(tokenCfg.BlobSidecarBuilder); err != nil {
+		return err
+	}
+	return nil
+}
+
+// setBlobFeeDefaults maxFeePerBlobGas = blobBaseFee * 2
+func (b *Bridge) setBlobFeeDefaults(extra *tokens.EthExtraArgs) error {
+	if extra.BlobFeeCap != nil {
+		return nil
+	}
+	blobBaseFee, err := b.GetBlobBaseFee()
+	if err != nil {
+		log.Warn("get blob base fee failed", "err", err)
+		return err
+	}
+	extra.BlobFeeCap = new(big.Int).Mul(blobBaseFee, big.NewInt(2))
+	return nil
+}
+
+// buildBlobSidecar build and validate sidecar of blob tx by the pair's builder
+func (b *Bridge) buildBlobSidecar(args *tokens.BuildTxArgs, input []byte) (*types.BlobTxSidecar, error) {
+	tokenCfg := b.GetTokenConfig(args.PairID)
+	if tokenCfg == nil {
+		return nil, tokens.ErrUnknownPairID
+	}
+	builder, err := getBlobSidecarBuilder(tokenCfg.BlobSidecarBuilder)
+	if err != nil {
+		return nil, err
+	}
+	sidecar, err := builder(args, input)
+	if err != nil {
+		return nil, err
+	}
+	if sidecar == nil {
+		return nil, errors.New("blob sidecar builder return nil sidecar")
+	}
+	if err = sidecar.Validate(); err != nil {
+		return nil, err
+	}
+	return sidecar, nil
+}
+
+// calcBlobGasFee max blob gas fee of sidecar
+func calcBlobGasFee(sidecar *types.BlobTxSidecar, blobFeeCap *big.Int) *big.Int {
+	blobGas := uint64(len(sidecar.Blobs)) * types.BlobGasPerBlob
+	return new(big.Int).Mul(blobFeeCap, new(big.Int).SetUint64(blobGas))
+}
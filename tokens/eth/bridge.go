@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/anyswap/CrossChain-Bridge/common"
 	"github.com/anyswap/CrossChain-Bridge/log"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
 	"github.com/anyswap/CrossChain-Bridge/types"
@@ -22,6 +23,12 @@ type Bridge struct {
 	*tokens.CrossChainBridgeBase
 	*NonceSetterBase
 	Signer types.Signer
+
+	testStateProvider StateProvider
+
+	gasPriceCache gasPriceCache
+
+	wrongChain int32 // set if gateway chain id mismatch (atomic)
 }
 
 // NewCrossChainBridge new bridge
@@ -41,7 +48,11 @@ func (b *Bridge) SetChainAndGateway(chainCfg *tokens.ChainConfig, gatewayCfg *to
 
 // Init init after verify
 func (b *Bridge) Init() {
+	if err := b.verifyL1FeeEstimator(); err != nil {
+		log.Fatal("verify chain config failed", "err", err)
+	}
 	InitExtCodeParts()
+	initResponseValidators()
 	b.InitLatestBlockNumber()
 }
 
@@ -89,7 +100,8 @@ func (b *Bridge) VerifyChainID() {
 		log.Fatalf("unsupported ethereum network %v", networkID)
 	}
 
-	b.Signer = types.MakeSigner("EIP155", chainID)
+	b.AssertExpectedChainID()
+	b.Signer = types.MakeSigner("London", chainID)
 
 	log.Info("VerifyChainID succeed", "networkID", networkID, "chainID", chainID)
 }
@@ -113,9 +125,66 @@ func (b *Bridge) VerifyTokenConfig(tokenCfg *tokens.TokenConfig) (err error) {
 		return err
 	}
 
+	err = b.verifyTxType(tokenCfg)
+	if err != nil {
+		return err
+	}
+
+	if tokenCfg.UseSafeTransfer && !b.IsValidAddress(b.ChainConfig.SafeTransferHelper) {
+		return fmt.Errorf("'UseSafeTransfer' require valid 'SafeTransferHelper' in chain config, but got '%v'", b.ChainConfig.SafeTransferHelper)
+	}
+
+	if b.IsSrc && tokenCfg.BatchWindow > 0 && !b.IsValidAddress(b.ChainConfig.BatchTransferHelper) {
+		return fmt.Errorf("'BatchWindow' require valid 'BatchTransferHelper' in chain config, but got '%v'", b.ChainConfig.BatchTransferHelper)
+	}
+
+	err = b.verifySwapContractOwner(tokenCfg)
+	if err != nil {
+		return err
+	}
+
+	b.checkErc20Symbol(tokenCfg)
+	b.checkUpgradeableContract(tokenCfg)
+
 	return nil
 }
 
+// swapin reverts if authorized caller of swap contract is not the dcrm address,
+// only log warning if the contract has neither `mpc()` nor `owner()`
+func (b *Bridge) verifySwapContractOwner(tokenCfg *tokens.TokenConfig) error {
+	if b.IsSrc || tokenCfg.ContractAddress == "" {
+		return nil
+	}
+	owner, err := b.getContractOwner(tokenCfg.ContractAddress)
+	if err != nil {
+		log.Warn("get swap contract owner failed", "contract", tokenCfg.ContractAddress, "err", err)
+		return nil
+	}
+	if owner != common.HexToAddress(tokenCfg.DcrmAddress) {
+		return fmt.Errorf("swap contract %v owner mismatch, have %v want %v", tokenCfg.ContractAddress, owner.String(), tokenCfg.DcrmAddress)
+	}
+	log.Info(tokenCfg.Symbol+" verify swap contract owner success", "contract", tokenCfg.ContractAddress, "owner", owner.String())
+	return nil
+}
+
+// only log warning if erc20 symbol mismatch, as symbol is not unique
+func (b *Bridge) checkErc20Symbol(tokenCfg *tokens.TokenConfig) {
+	if !tokenCfg.IsErc20() {
+		return
+	}
+	symbol, err := b.GetErc20Symbol(tokenCfg.ContractAddress)
+	if err != nil {
+		log.Warn("get erc20 symbol failed", "contract", tokenCfg.ContractAddress, "err", err)
+		return
+	}
+	name, _ := b.GetErc20Name(tokenCfg.ContractAddress)
+	if !strings.EqualFold(symbol, tokenCfg.Symbol) {
+		log.Warn("erc20 symbol mismatch", "contract", tokenCfg.ContractAddress, "symbol", symbol, "name", name, "configed", tokenCfg.Symbol)
+		return
+	}
+	log.Info(tokenCfg.Symbol+" verify symbol success", "symbol", symbol, "name", name)
+}
+
 func (b *Bridge) verifyDecimals(tokenCfg *tokens.TokenConfig) error {
 	configedDecimals := *tokenCfg.Decimals
 	switch strings.ToUpper(tokenCfg.Symbol) {
@@ -4,11 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"strings"
 	"time"
 
 	"github.com/anyswap/CrossChain-Bridge/common"
 	"github.com/anyswap/CrossChain-Bridge/log"
 	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/rpc/client"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
 	"github.com/anyswap/CrossChain-Bridge/types"
 )
@@ -20,10 +22,62 @@ var (
 	defReserveGasFee = big.NewInt(1e16) // 0.01 ETH
 )
 
+// buildOptions options of building one tx
+type buildOptions struct {
+	logger  *log.Logger
+	offline bool     // skip all rpc calls
+	chainID *big.Int // chain id used in offline mode
+	blobFee *big.Int // max blob gas fee of blob tx, reserved in balance check
+}
+
 // BuildRawTransaction build raw tx
 func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{}, err error) {
+	opts := &buildOptions{logger: newBuildLogger(args.PairID)}
+	return b.buildRawTransaction(args, opts)
+}
+
+// BuildRawTransactionOffline build raw tx with the provided nonce, gas price,
+// gas limit and chain id, and without any rpc call (eg. for air-gapped signing)
+func (b *Bridge) BuildRawTransactionOffline(args *tokens.BuildTxArgs, nonce uint64, gasPrice *big.Int, gasLimit uint64, chainID *big.Int) (rawTx interface{}, err error) {
+	if gasPrice == nil || gasPrice.Sign() <= 0 {
+		return nil, errors.New("offline build tx require positive gas price")
+	}
+	if gasLimit == 0 {
+		return nil, errors.New("offline build tx require positive gas limit")
+	}
+	if chainID == nil || chainID.Sign() <= 0 {
+		return nil, errors.New("offline build tx require positive chain id")
+	}
+	if b.isBlobTx(args.PairID) {
+		return nil, errors.New("offline build blob tx is not supported")
+	}
+	extra := &tokens.EthExtraArgs{
+		Gas:      &gasLimit,
+		GasPrice: gasPrice,
+		Nonce:    &nonce,
+	}
+	if b.isDynamicFeeTx(args.PairID) {
+		extra.GasTipCap = gasPrice
+		extra.GasFeeCap = gasPrice
+	}
+	args.Extra = &tokens.AllExtras{EthExtra: extra}
+	opts := &buildOptions{
+		logger:  newBuildLogger(args.PairID),
+		offline: true,
+		chainID: chainID,
+	}
+	return b.buildRawTransaction(args, opts)
+}
+
+func (b *Bridge) buildRawTransaction(args *tokens.BuildTxArgs, opts *buildOptions) (rawTx interface{}, err error) {
+	if b.IsWrongChain() {
+		return nil, tokens.ErrWrongChain
+	}
 	var input []byte
 	var tokenCfg *tokens.TokenConfig
+	if args.SwapType != tokens.NoSwapType {
+		opts.logger = opts.logger.With("traceID", args.EnsureTraceID())
+	}
 	if args.Input == nil {
 		if args.SwapType != tokens.NoSwapType {
 			pairID := args.PairID
@@ -31,8 +85,30 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 			if tokenCfg == nil {
 				return nil, tokens.ErrUnknownPairID
 			}
-			if args.From == "" {
+			if tokens.IsPairDisabled(pairID) {
+				return nil, tokens.ErrPairDisabled
+			}
+			if err = b.checkContractPaused(tokenCfg, opts); err != nil {
+				return nil, err
+			}
+			if !opts.offline {
+				// origin value is of the other side
+				err = tokens.CheckSwapUSDValue(tokenCfg, pairID, args.OriginValue, !b.IsSrc)
+				if err != nil {
+					return nil, err
+				}
+			}
+			if args.From == "" && opts.offline {
 				args.From = tokenCfg.DcrmAddress // from
+			} else if args.From == "" || b.shouldSelectSigner(tokenCfg, args) {
+				var requiredValue *big.Int
+				if args.SwapType == tokens.SwapoutType && !tokenCfg.IsErc20() {
+					requiredValue = tokens.CalcSwappedValue(pairID, args.OriginValue, false)
+				}
+				args.From, err = b.selectSigner(pairID, requiredValue) // from
+				if err != nil {
+					return nil, err
+				}
 			}
 		}
 		switch args.SwapType {
@@ -40,7 +116,19 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 			if b.IsSrc {
 				return nil, tokens.ErrBuildSwapTxInWrongEndpoint
 			}
-			err = b.buildSwapinTxInput(args)
+			if tokenCfg.CheckSwapinCompleted && !opts.offline {
+				var completed bool
+				completed, err = b.IsSwapinCompleted(args.PairID, args.SwapID)
+				if err != nil {
+					opts.logger.Warn("query swapin completed failed", "pairID", args.PairID, "swapID", args.SwapID, "err", err)
+					return nil, err
+				}
+				if completed {
+					opts.logger.Warn("swapin is already completed on chain", "pairID", args.PairID, "swapID", args.SwapID)
+					return nil, tokens.ErrSwapinCompleted
+				}
+			}
+			err = b.buildSwapinTxInput(args, opts)
 			if err != nil {
 				return nil, err
 			}
@@ -50,7 +138,7 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 				return nil, tokens.ErrBuildSwapTxInWrongEndpoint
 			}
 			if tokenCfg.IsErc20() {
-				err = b.buildErc20SwapoutTxInput(args)
+				err = b.buildErc20SwapoutTxInput(args, opts)
 				if err != nil {
 					return nil, err
 				}
@@ -60,6 +148,12 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 				input = []byte(tokens.UnlockMemoPrefix + args.SwapID)
 			}
 		}
+		if tokenCfg != nil {
+			input = tagSwapInput(tokenCfg, input, args.SwapType == tokens.SwapoutType && !tokenCfg.IsErc20())
+			if tokenCfg.TraceIDInCalldata {
+				input = append(common.CopyBytes(input), common.FromHex(args.TraceID)...)
+			}
+		}
 	} else {
 		input = *args.Input
 		if args.SwapType != tokens.NoSwapType {
@@ -72,10 +166,10 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 		return nil, err
 	}
 
-	return b.buildTx(args, extra, input)
+	return b.buildTx(args, extra, input, opts)
 }
 
-func (b *Bridge) buildTx(args *tokens.BuildTxArgs, extra *tokens.EthExtraArgs, input []byte) (rawTx interface{}, err error) {
+func (b *Bridge) buildTx(args *tokens.BuildTxArgs, extra *tokens.EthExtraArgs, input []byte, opts *buildOptions) (rawTx interface{}, err error) {
 	var (
 		to       = common.HexToAddress(args.To)
 		value    = args.Value
@@ -84,6 +178,11 @@ func (b *Bridge) buildTx(args *tokens.BuildTxArgs, extra *tokens.EthExtraArgs, i
 		gasPrice = extra.GasPrice
 	)
 
+	isDynamicFeeTx := b.isDynamicFeeTx(args.PairID)
+	if isDynamicFeeTx {
+		gasPrice = extra.GasFeeCap
+	}
+
 	if args.SwapType == tokens.SwapoutType {
 		pairID := args.PairID
 		tokenCfg := b.GetTokenConfig(pairID)
@@ -91,49 +190,235 @@ func (b *Bridge) buildTx(args *tokens.BuildTxArgs, extra *tokens.EthExtraArgs, i
 			return nil, tokens.ErrUnknownPairID
 		}
 		if !tokenCfg.IsErc20() {
+			if args.NativeValue != nil && args.NativeValue.Sign() > 0 {
+				return nil, errors.New("forbid native value in non erc20 swapout")
+			}
 			value = tokens.CalcSwappedValue(pairID, args.OriginValue, false)
+			if err = checkSwappedValue(tokenCfg, value, opts); err != nil {
+				return nil, err
+			}
 		}
 	}
 
+	if args.NativeValue != nil && args.NativeValue.Sign() > 0 {
+		value = args.NativeValue
+	}
+
 	if args.SwapType != tokens.NoSwapType {
 		args.Identifier = params.GetIdentifier()
 	}
 
-	var balance *big.Int
-	for i := 0; i < retryRPCCount; i++ {
-		balance, err = b.GetBalance(args.From)
-		if err == nil {
-			break
+	maxTxDataSize := b.ChainConfig.MaxTxDataSize
+	if maxTxDataSize > 0 && uint64(len(input)) > maxTxDataSize {
+		opts.logger.Warn("build tx with too large data", "size", len(input), "max", maxTxDataSize)
+		return nil, tokens.ErrTxDataTooLarge
+	}
+
+	var sidecar *types.BlobTxSidecar
+	isBlobTx := b.isBlobTx(args.PairID)
+	if isBlobTx {
+		sidecar, err = b.buildBlobSidecar(args, input)
+		if err != nil {
+			opts.logger.Warn("build blob sidecar failed", "err", err)
+			return nil, err
 		}
-		time.Sleep(retryRPCInterval)
+		opts.blobFee = calcBlobGasFee(sidecar, extra.BlobFeeCap)
+	}
+
+	if isDynamicFeeTx {
+		chainID := opts.chainID
+		if !opts.offline {
+			signer, ok := b.Signer.(types.LondonSigner)
+			if !ok {
+				return nil, errors.New("dynamic fee tx require london signer")
+			}
+			chainID = signer.ChainID()
+		}
+		if isBlobTx {
+			rawTx = types.NewBlobTx(chainID, nonce, to, value, gasLimit, extra.GasTipCap, extra.GasFeeCap, extra.BlobFeeCap, input, sidecar)
+		} else {
+			rawTx = types.NewDynamicFeeTx(chainID, nonce, &to, value, gasLimit, extra.GasTipCap, extra.GasFeeCap, input)
+		}
+	} else {
+		rawTx = types.NewTransaction(nonce, to, value, gasLimit, gasPrice, input)
 	}
+
+	if !opts.offline {
+		err = b.checkBlockGasLimit(gasLimit, opts)
+		if err != nil {
+			return nil, err
+		}
+		err = b.checkCoinBalance(args, rawTx.(*types.Transaction), gasPrice, gasLimit, opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	opts.logger.Trace("build raw tx", "pairID", args.PairID, "identifier", args.Identifier,
+		"swapID", args.SwapID, "swapType", args.SwapType,
+		"bind", args.Bind, "originValue", args.OriginValue,
+		"from", args.From, "to", to.String(), "value", value, "nonce", nonce,
+		"gasLimit", gasLimit, "gasPrice", gasPrice, "gasTipCap", extra.GasTipCap,
+		"blobFeeCap", extra.BlobFeeCap, "data", common.ToHex(input))
+
+	return rawTx, nil
+}
+
+// checkBlockGasLimit only log warning if get block gas limit failed
+func (b *Bridge) checkBlockGasLimit(gasLimit uint64, opts *buildOptions) error {
+	if !b.ChainConfig.CheckBlockGasLimit {
+		return nil
+	}
+	blockGasLimit, err := b.GetBlockGasLimit()
 	if err != nil {
-		log.Warn("get balance error", "from", args.From, "err", err)
-		return nil, fmt.Errorf("get balance error: %v", err)
+		opts.logger.Warn("get block gas limit failed", "err", err)
+		return nil
 	}
+	if gasLimit > blockGasLimit {
+		opts.logger.Warn("build tx with too large gas limit", "gasLimit", gasLimit, "blockGasLimit", blockGasLimit)
+		return tokens.ErrGasLimitExceedsBlock
+	}
+	return nil
+}
+
+func (b *Bridge) checkCoinBalance(args *tokens.BuildTxArgs, tx *types.Transaction, gasPrice *big.Int, gasLimit uint64, opts *buildOptions) (err error) {
 	needValue := big.NewInt(0)
-	if value != nil && value.Sign() > 0 {
+	if value := tx.Value(); value != nil && value.Sign() > 0 {
 		needValue = value
 	}
-	if args.SwapType != tokens.NoSwapType {
-		needValue = new(big.Int).Add(needValue, defReserveGasFee)
-	} else {
+	feeToken := b.getFeeToken(args.PairID)
+	if feeToken != "" {
+		// gas fee is denominated in fee token
 		gasFee := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gasLimit))
-		needValue = new(big.Int).Add(needValue, gasFee)
+		if args.SwapType != tokens.NoSwapType {
+			if reserve := b.getReserveGasFee(args.PairID, gasPrice, gasLimit); reserve != nil {
+				gasFee = reserve
+			}
+		}
+		if opts.blobFee != nil {
+			gasFee = new(big.Int).Add(gasFee, opts.blobFee)
+		}
+		err = b.checkFeeTokenBalance(feeToken, args.From, gasFee, opts)
+		if err != nil {
+			return err
+		}
+	} else {
+		if args.SwapType != tokens.NoSwapType {
+			reserve := b.getReserveGasFee(args.PairID, gasPrice, gasLimit)
+			if reserve == nil {
+				reserve = defReserveGasFee
+			}
+			needValue = new(big.Int).Add(needValue, reserve)
+		} else {
+			gasFee := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gasLimit))
+			needValue = new(big.Int).Add(needValue, gasFee)
+		}
+		l1Fee, errf := b.estimateL1Fee(tx)
+		if errf != nil {
+			opts.logger.Warn("estimate l1 fee error", "err", errf)
+			return fmt.Errorf("estimate l1 fee error: %v", errf)
+		}
+		needValue = new(big.Int).Add(needValue, l1Fee)
+		if opts.blobFee != nil {
+			needValue = new(big.Int).Add(needValue, opts.blobFee)
+		}
+	}
+	if minReserve := b.getMinReserveBalance(args.PairID, args.From); minReserve.Sign() > 0 {
+		needValue = new(big.Int).Add(needValue, minReserve)
+	}
+
+	balance, err := b.getCoinBalance(args.From)
+	if err != nil {
+		opts.logger.Warn("get balance error", "from", args.From, "err", err)
+		return fmt.Errorf("get balance error: %v", err)
 	}
 	if balance.Cmp(needValue) < 0 {
-		return nil, errors.New("not enough coin balance")
+		return errors.New("not enough coin balance")
 	}
+	return nil
+}
 
-	rawTx = types.NewTransaction(nonce, to, value, gasLimit, gasPrice, input)
+func (b *Bridge) getCoinBalance(account string) (balance *big.Int, err error) {
+	for i := 0; i < retryRPCCount; i++ {
+		if b.testStateProvider != nil {
+			balance, err = b.testStateProvider.GetBalance(account)
+		} else {
+			balance, err = b.GetBalance(account)
+		}
+		if err == nil {
+			return balance, nil
+		}
+		if !client.IsTransientError(err) {
+			break
+		}
+		time.Sleep(retryRPCInterval)
+	}
+	return nil, err
+}
 
-	log.Trace("build raw tx", "pairID", args.PairID, "identifier", args.Identifier,
-		"swapID", args.SwapID, "swapType", args.SwapType,
-		"bind", args.Bind, "originValue", args.OriginValue,
-		"from", args.From, "to", to.String(), "value", value, "nonce", nonce,
-		"gasLimit", gasLimit, "gasPrice", gasPrice, "data", common.ToHex(input))
+func (b *Bridge) getErc20Balance(contract, account string) (balance *big.Int, err error) {
+	for i := 0; i < retryRPCCount; i++ {
+		if b.testStateProvider != nil {
+			balance, err = b.testStateProvider.GetErc20Balance(contract, account)
+		} else {
+			balance, err = b.GetErc20Balance(contract, account)
+		}
+		if err == nil {
+			return balance, nil
+		}
+		if !client.IsTransientError(err) {
+			break
+		}
+		time.Sleep(retryRPCInterval)
+	}
+	return nil, err
+}
 
-	return rawTx, nil
+// getErc20Balances get erc20 balances of account in one rpc through
+// Multicall3 contract if configed, otherwise query them one by one
+func (b *Bridge) getErc20Balances(contracts []string, account string) (balances []*big.Int, err error) {
+	if b.testStateProvider == nil && b.ChainConfig.MulticallAddress != "" {
+		for i := 0; i < retryRPCCount; i++ {
+			balances, err = b.GetErc20Balances(contracts, account)
+			if err == nil {
+				return balances, nil
+			}
+			if !client.IsTransientError(err) {
+				break
+			}
+			time.Sleep(retryRPCInterval)
+		}
+		return nil, err
+	}
+	balances = make([]*big.Int, len(contracts))
+	for i, contract := range contracts {
+		balances[i], err = b.getErc20Balance(contract, account)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return balances, nil
+}
+
+func (b *Bridge) getFeeToken(pairID string) string {
+	tokenCfg := b.GetTokenConfig(pairID)
+	if tokenCfg == nil {
+		return ""
+	}
+	return tokenCfg.FeeToken
+}
+
+func (b *Bridge) checkFeeTokenBalance(feeToken, from string, gasFee *big.Int, opts *buildOptions) error {
+	balance, err := b.getErc20Balance(feeToken, from)
+	if err != nil {
+		opts.logger.Warn("get fee token balance error", "feeToken", feeToken, "from", from, "err", err)
+		return fmt.Errorf("get fee token balance error: %v", err)
+	}
+	if balance.Cmp(gasFee) < 0 {
+		opts.logger.Warn("not enough fee token balance", "feeToken", feeToken, "from", from, "balance", balance, "gasFee", gasFee)
+		return errors.New("not enough fee token balance")
+	}
+	return nil
 }
 
 func (b *Bridge) setDefaults(args *tokens.BuildTxArgs) (extra *tokens.EthExtraArgs, err error) {
@@ -146,24 +431,24 @@ func (b *Bridge) setDefaults(args *tokens.BuildTxArgs) (extra *tokens.EthExtraAr
 	} else {
 		extra = args.Extra.EthExtra
 	}
-	if extra.GasPrice == nil {
-		extra.GasPrice, err = b.getGasPrice()
+	if b.isDynamicFeeTx(args.PairID) {
+		err = b.setDynamicFeeDefaults(args, extra)
 		if err != nil {
 			return nil, err
 		}
-		if args.SwapType != tokens.NoSwapType {
-			pairID := args.PairID
-			tokenCfg := b.GetTokenConfig(pairID)
-			if tokenCfg == nil {
-				return nil, tokens.ErrUnknownPairID
-			}
-			addPercent := tokenCfg.PlusGasPricePercentage
-			if addPercent > 0 {
-				extra.GasPrice.Mul(extra.GasPrice, big.NewInt(int64(100+addPercent)))
-				extra.GasPrice.Div(extra.GasPrice, big.NewInt(100))
+		if b.isBlobTx(args.PairID) {
+			err = b.setBlobFeeDefaults(extra)
+			if err != nil {
+				return nil, err
 			}
 		}
+	} else if extra.GasPrice == nil {
+		extra.GasPrice, err = b.getSwapGasPrice(args)
+		if err != nil {
+			return nil, err
+		}
 	}
+	b.applyGasPriceBounds(args, extra)
 	if extra.Nonce == nil {
 		extra.Nonce, err = b.getAccountNonce(args.PairID, args.From, args.SwapType)
 		if err != nil {
@@ -172,15 +457,25 @@ func (b *Bridge) setDefaults(args *tokens.BuildTxArgs) (extra *tokens.EthExtraAr
 	}
 	if extra.Gas == nil {
 		extra.Gas = new(uint64)
-		*extra.Gas = b.getDefaultGasLimit(args.PairID)
+		*extra.Gas = b.getDefaultGasLimit(args.PairID, args.SwapType)
 	}
 	return extra, nil
 }
 
-func (b *Bridge) getDefaultGasLimit(pairID string) (gasLimit uint64) {
+// newBuildLogger log level can be overridden by module 'build' or 'pair:<pairID>'
+func newBuildLogger(pairID string) *log.Logger {
+	logger := log.NewModuleLogger("build")
+	if pairID != "" {
+		logger = logger.WithModule("pair:" + strings.ToLower(pairID))
+	}
+	return logger
+}
+
+// getDefaultGasLimit fallback order: by swap type, 'DefaultGasLimit', package default
+func (b *Bridge) getDefaultGasLimit(pairID string, swapType tokens.SwapType) (gasLimit uint64) {
 	tokenCfg := b.GetTokenConfig(pairID)
 	if tokenCfg != nil {
-		gasLimit = tokenCfg.DefaultGasLimit
+		gasLimit = tokenCfg.GetDefaultGasLimit(swapType)
 	}
 	if gasLimit == 0 {
 		gasLimit = 90000
@@ -188,12 +483,107 @@ func (b *Bridge) getDefaultGasLimit(pairID string) (gasLimit uint64) {
 	return gasLimit
 }
 
+// isReservedSwapinAddress swapin to zero address, swap contract or dcrm address
+// would burn or lock the minted tokens, which is always an operator error
+func isReservedSwapinAddress(token *tokens.TokenConfig, address common.Address) bool {
+	if address == (common.Address{}) {
+		return true
+	}
+	if token.ContractAddress != "" && address == common.HexToAddress(token.ContractAddress) {
+		return true
+	}
+	return token.DcrmAddress != "" && address == common.HexToAddress(token.DcrmAddress)
+}
+
+// tagSwapInput insert 'DataPrefix' after function selector (or before memo)
+// and append 'DataSuffix' after args (or after memo)
+func tagSwapInput(tokenCfg *tokens.TokenConfig, input []byte, isMemo bool) []byte {
+	prefix := common.FromHex(tokenCfg.DataPrefix)
+	suffix := common.FromHex(tokenCfg.DataSuffix)
+	if len(prefix) == 0 && len(suffix) == 0 {
+		return input
+	}
+	selectorLen := 4
+	if isMemo || len(input) < selectorLen {
+		selectorLen = 0
+	}
+	tagged := make([]byte, 0, len(input)+len(prefix)+len(suffix))
+	tagged = append(tagged, input[:selectorLen]...)
+	tagged = append(tagged, prefix...)
+	tagged = append(tagged, input[selectorLen:]...)
+	tagged = append(tagged, suffix...)
+	return tagged
+}
+
+// checkSwappedValue reject swap with zero or negative swapped value
+// (building it only wastes gas) unless 'AllowZeroValueSwap' is configed
+func checkSwappedValue(tokenCfg *tokens.TokenConfig, value *big.Int, opts *buildOptions) error {
+	if value == nil || value.Sign() <= 0 {
+		if tokenCfg.AllowZeroValueSwap {
+			return nil
+		}
+		return tokens.ErrZeroSwapValue
+	}
+	if tokenCfg.MinTransferAmount > 0 && tokenCfg.Decimals != nil {
+		minTransfer := tokens.ToBits(tokenCfg.MinTransferAmount, *tokenCfg.Decimals)
+		if value.Cmp(minTransfer) < 0 {
+			opts.logger.Warn("swapped value is below min transfer amount", "value", value, "minTransfer", minTransfer)
+			return tokens.ErrBelowMinTransfer
+		}
+	}
+	return nil
+}
+
+// calc min amount out by deducting slippage tolerance from the quoted amount
+func (b *Bridge) calcMinAmountOut(tokenCfg *tokens.TokenConfig, amount *big.Int) *big.Int {
+	tolerance := tokenCfg.SlippageTolerance
+	if tolerance == 0 {
+		tolerance = b.ChainConfig.DefaultSlippageTolerance
+	}
+	if amount == nil || amount.Sign() <= 0 {
+		return big.NewInt(0)
+	}
+	// tolerance in basis points
+	bps := int64(tolerance * 100)
+	minAmountOut := new(big.Int).Mul(amount, big.NewInt(10000-bps))
+	return minAmountOut.Div(minAmountOut, big.NewInt(10000))
+}
+
+// get min reserve balance if 'from' is the dcrm address of pair
+func (b *Bridge) getMinReserveBalance(pairID, from string) *big.Int {
+	tokenCfg := b.GetTokenConfig(pairID)
+	if tokenCfg == nil || tokenCfg.MinReserveBalance <= 0 || !strings.EqualFold(from, tokenCfg.DcrmAddress) {
+		return big.NewInt(0)
+	}
+	return tokens.ToBits(tokenCfg.MinReserveBalance, b.ChainConfig.GetNativeDecimals())
+}
+
+// get reserve gas fee of swap tx if 'ReserveGasFeeBlocks' is configed, otherwise return nil
+func (b *Bridge) getReserveGasFee(pairID string, gasPrice *big.Int, gasLimit uint64) *big.Int {
+	tokenCfg := b.GetTokenConfig(pairID)
+	if tokenCfg == nil || tokenCfg.ReserveGasFeeBlocks == 0 || gasPrice == nil {
+		return nil
+	}
+	reserve := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gasLimit))
+	return reserve.Mul(reserve, new(big.Int).SetUint64(tokenCfg.ReserveGasFeeBlocks))
+}
+
 func (b *Bridge) getGasPrice() (price *big.Int, err error) {
+	if b.testStateProvider != nil {
+		return b.testStateProvider.GetGasPrice()
+	}
+	if price = b.gasPriceCache.get(b.getGasPriceCacheTTL()); price != nil {
+		return price, nil
+	}
 	for i := 0; i < retryRPCCount; i++ {
 		price, err = b.SuggestPrice()
 		if err == nil {
+			b.gasPriceCache.set(price)
 			return price, nil
 		}
+		if !client.IsTransientError(err) {
+			break
+		}
 		time.Sleep(retryRPCInterval)
 	}
 	return nil, err
@@ -202,10 +592,19 @@ func (b *Bridge) getGasPrice() (price *big.Int, err error) {
 func (b *Bridge) getAccountNonce(pairID, from string, swapType tokens.SwapType) (nonceptr *uint64, err error) {
 	var nonce uint64
 	for i := 0; i < retryRPCCount; i++ {
-		nonce, err = b.GetPoolNonce(from, "pending")
+		if b.testStateProvider != nil {
+			nonce, err = b.testStateProvider.GetPoolNonce(from)
+		} else if b.GatewayConfig.MaxNonceOfAllGateways {
+			nonce, err = b.GetMaxNonceOfAllGateways(from, b.GatewayConfig.GetNonceBlockTag())
+		} else {
+			nonce, err = b.GetNonce(from, b.GatewayConfig.GetNonceBlockTag())
+		}
 		if err == nil {
 			break
 		}
+		if !client.IsTransientError(err) {
+			break
+		}
 		time.Sleep(retryRPCInterval)
 	}
 	if err != nil {
@@ -214,62 +613,123 @@ func (b *Bridge) getAccountNonce(pairID, from string, swapType tokens.SwapType)
 	if swapType != tokens.NoSwapType {
 		tokenCfg := b.GetTokenConfig(pairID)
 		if tokenCfg != nil && from == tokenCfg.DcrmAddress {
+			// adjusted nonce is already aligned
 			nonce = b.AdjustNonce(pairID, nonce)
+			return &nonce, nil
 		}
 	}
+	nonce = b.alignNonce(nonce)
 	return &nonce, nil
 }
 
 // build input for calling `Swapin(bytes32 txhash, address account, uint256 amount)`
-func (b *Bridge) buildSwapinTxInput(args *tokens.BuildTxArgs) error {
+// (with trailing `uint256 deadline` if tx has deadline and 'SwapinDeadlineFuncHash' is configed)
+func (b *Bridge) buildSwapinTxInput(args *tokens.BuildTxArgs, opts *buildOptions) error {
 	pairID := args.PairID
-	funcHash := getSwapinFuncHash()
 	txHash := common.HexToHash(args.SwapID)
-	address := common.HexToAddress(args.Bind)
-	if address == (common.Address{}) || !common.IsHexAddress(args.Bind) {
-		log.Warn("swapin to wrong address", "address", args.Bind)
+	bind := args.Bind
+	if b.isEnsEnabled() && IsEnsName(bind) {
+		if opts.offline {
+			return errors.New("can not resolve ens name offline")
+		}
+		resolved, err := b.ResolveEnsName(bind)
+		if err != nil {
+			opts.logger.Warn("resolve ens name failed", "name", bind, "err", err)
+			return err
+		}
+		opts.logger.Info("resolve ens name success", "name", bind, "address", resolved)
+		bind = resolved
+	}
+	token := b.GetTokenConfig(pairID)
+	if token == nil {
+		return tokens.ErrUnknownPairID
+	}
+	if !common.IsHexAddress(bind) {
+		opts.logger.Warn("swapin to wrong address", "address", bind)
 		return errors.New("can not swapin to empty or invalid address")
 	}
+	address := common.HexToAddress(bind)
+	if isReservedSwapinAddress(token, address) {
+		opts.logger.Warn("swapin to reserved address", "address", bind, "contract", token.ContractAddress, "dcrm", token.DcrmAddress)
+		return tokens.ErrSwapinToReservedAddress
+	}
+	if token.StrictBindChecksum && !IsValidChecksumAddress(bind) {
+		opts.logger.Warn("swapin to address with wrong checksum", "address", bind, "checksumed", address.String())
+		return tokens.ErrBindAddressChecksum
+	}
+	if err := b.checkRecipientBlacklisted(token, bind, opts); err != nil {
+		return err
+	}
 	amount := tokens.CalcSwappedValue(pairID, args.OriginValue, true)
+	if err := checkSwappedValue(token, amount, opts); err != nil {
+		return err
+	}
 
-	input := PackDataWithFuncHash(funcHash, txHash, address, amount)
+	var input []byte
+	if !args.Deadline.IsZero() && token.SwapinDeadlineFuncHash != "" {
+		deadlineFuncHash := common.FromHex(token.SwapinDeadlineFuncHash)
+		input = PackDataWithFuncHash(deadlineFuncHash, txHash, address, amount, big.NewInt(args.Deadline.Unix()))
+	} else if token.SwapinMinAmountOutFuncHash != "" {
+		minAmountOutFuncHash := common.FromHex(token.SwapinMinAmountOutFuncHash)
+		minAmountOut := b.calcMinAmountOut(token, amount)
+		input = PackDataWithFuncHash(minAmountOutFuncHash, txHash, address, amount, minAmountOut)
+	} else {
+		input = EncodeSwapinInput(txHash, address, amount)
+	}
 	args.Input = &input // input
 
-	token := b.GetTokenConfig(pairID)
-	if token == nil {
-		return tokens.ErrUnknownPairID
-	}
 	args.To = token.ContractAddress // to
 	return nil
 }
 
-func (b *Bridge) buildErc20SwapoutTxInput(args *tokens.BuildTxArgs) (err error) {
+func (b *Bridge) buildErc20SwapoutTxInput(args *tokens.BuildTxArgs, opts *buildOptions) (err error) {
 	pairID := args.PairID
-	funcHash := erc20CodeParts["transfer"]
+	token := b.GetTokenConfig(pairID)
+	if token == nil {
+		return tokens.ErrUnknownPairID
+	}
 	address := common.HexToAddress(args.Bind)
 	if address == (common.Address{}) || !common.IsHexAddress(args.Bind) {
-		log.Warn("swapout to wrong address", "address", args.Bind)
+		opts.logger.Warn("swapout to wrong address", "address", args.Bind)
 		return errors.New("can not swapout to empty or invalid address")
 	}
+	if token.StrictBindChecksum && !IsValidChecksumAddress(args.Bind) {
+		opts.logger.Warn("swapout to address with wrong checksum", "address", args.Bind, "checksumed", address.String())
+		return tokens.ErrBindAddressChecksum
+	}
+	if err = b.checkRecipientBlacklisted(token, args.Bind, opts); err != nil {
+		return err
+	}
 	amount := tokens.CalcSwappedValue(pairID, args.OriginValue, false)
+	if err = checkSwappedValue(token, amount, opts); err != nil {
+		return err
+	}
 
-	input := PackDataWithFuncHash(funcHash, address, amount)
-	args.Input = &input // input
+	if token.UseSafeTransfer {
+		helper := b.ChainConfig.SafeTransferHelper
+		if helper == "" {
+			return errors.New("safe transfer require 'SafeTransferHelper' in chain config")
+		}
+		input := EncodeSafeTransferInput(common.HexToAddress(token.ContractAddress), address, amount)
+		args.Input = &input // input
+		args.To = helper    // to
+	} else if token.UseTransferAndCall {
+		input := EncodeTransferAndCallInput(address, amount, common.FromHex(token.TransferAndCallData))
+		args.Input = &input // input
 
-	token := b.GetTokenConfig(pairID)
-	if token == nil {
-		return tokens.ErrUnknownPairID
+		args.To = token.ContractAddress // to
+	} else {
+		input := EncodeErc20TransferInput(address, amount)
+		args.Input = &input // input
+
+		args.To = token.ContractAddress // to
 	}
-	args.To = token.ContractAddress // to
 
-	var balance *big.Int
-	for i := 0; i < retryRPCCount; i++ {
-		balance, err = b.GetErc20Balance(token.ContractAddress, token.DcrmAddress)
-		if err == nil {
-			break
-		}
-		time.Sleep(retryRPCInterval)
+	if opts.offline {
+		return nil
 	}
+
+	balance, err := b.getErc20Balance(token.ContractAddress, args.From)
 	if err == nil && balance.Cmp(amount) < 0 {
 		return errors.New("not enough token balance to swapout")
 	}
@@ -0,0 +1,94 @@
+package eth
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+const (
+	testSwapContract = "0x1111111111111111111111111111111111111111"
+	testSwapDcrm     = "0x2222222222222222222222222222222222222222"
+)
+
+func TestBuildSwapinToReservedAddress(t *testing.T) {
+	pairID := "testreserved"
+	zeroFeeRate := 0.0
+	tokens.SetTokenPairsConfig(map[string]*tokens.TokenPairConfig{
+		pairID: {
+			PairID:   pairID,
+			SrcToken: &tokens.TokenConfig{DcrmAddress: testSwapDcrm, SwapFeeRate: &zeroFeeRate},
+			DestToken: &tokens.TokenConfig{
+				ContractAddress: testSwapContract,
+				DcrmAddress:     testSwapDcrm,
+				SwapFeeRate:     &zeroFeeRate,
+			},
+		},
+	}, false)
+	defer tokens.SetTokenPairsConfig(nil, false)
+
+	b := NewCrossChainBridge(false)
+	opts := &buildOptions{logger: newBuildLogger(pairID), offline: true}
+	newArgs := func(bind string) *tokens.BuildTxArgs {
+		return &tokens.BuildTxArgs{
+			SwapInfo: tokens.SwapInfo{
+				PairID:   pairID,
+				SwapID:   "0x3333333333333333333333333333333333333333333333333333333333333333",
+				SwapType: tokens.SwapinType,
+				Bind:     bind,
+			},
+			OriginValue: big.NewInt(1000),
+		}
+	}
+
+	cases := []struct {
+		name string
+		bind string
+	}{
+		{"zero address", "0x0000000000000000000000000000000000000000"},
+		{"contract address", testSwapContract},
+		{"dcrm address", testSwapDcrm},
+	}
+	for _, c := range cases {
+		err := b.buildSwapinTxInput(newArgs(c.bind), opts)
+		if err != tokens.ErrSwapinToReservedAddress {
+			t.Errorf("swapin to %v: want error %v, got %v", c.name, tokens.ErrSwapinToReservedAddress, err)
+		}
+	}
+
+	args := newArgs("0x4444444444444444444444444444444444444444")
+	if err := b.buildSwapinTxInput(args, opts); err != nil {
+		t.Fatalf("swapin to normal address failed: %v", err)
+	}
+	if args.To != testSwapContract || args.Input == nil {
+		t.Errorf("swapin to normal address built wrong tx args: to %v", args.To)
+	}
+}
+
+func TestGetMinReserveBalanceWithNativeDecimals(t *testing.T) {
+	pairID := "testminreserve"
+	tokens.SetTokenPairsConfig(map[string]*tokens.TokenPairConfig{
+		pairID: {
+			PairID:    pairID,
+			SrcToken:  &tokens.TokenConfig{DcrmAddress: testSwapDcrm},
+			DestToken: &tokens.TokenConfig{DcrmAddress: testSwapDcrm, MinReserveBalance: 1.5},
+		},
+	}, false)
+	defer tokens.SetTokenPairsConfig(nil, false)
+
+	b := NewCrossChainBridge(false)
+	b.ChainConfig = &tokens.ChainConfig{}
+	if got := b.getMinReserveBalance(pairID, testSwapDcrm); got.String() != "1500000000000000000" {
+		t.Errorf("min reserve balance with default 18 decimals: got %v", got)
+	}
+
+	nativeDecimals := uint8(8)
+	b.ChainConfig.NativeDecimals = &nativeDecimals
+	if got := b.getMinReserveBalance(pairID, testSwapDcrm); got.String() != "150000000" {
+		t.Errorf("min reserve balance with 8 decimals: got %v", got)
+	}
+	if got := b.getMinReserveBalance(pairID, testSwapContract); got.Sign() != 0 {
+		t.Errorf("min reserve balance of other address should be zero, got %v", got)
+	}
+}
@@ -1,17 +1,37 @@
 package eth
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/anyswap/CrossChain-Bridge/common"
 	"github.com/anyswap/CrossChain-Bridge/common/hexutil"
 	"github.com/anyswap/CrossChain-Bridge/rpc/client"
-	"github.com/anyswap/CrossChain-Bridge/tools/rlp"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
 	"github.com/anyswap/CrossChain-Bridge/types"
 )
 
+func initResponseValidators() {
+	client.RegisterResponseValidator("eth_blockNumber", validateBlockNumberResult)
+	client.RegisterResponseValidator("eth_gasPrice", client.NotEmptyResultValidator)
+	client.RegisterResponseValidator("eth_getTransactionCount", client.NotEmptyResultValidator)
+	client.RegisterResponseValidator("eth_getBalance", client.NotEmptyResultValidator)
+}
+
+// a synced node never reports block number 0
+func validateBlockNumberResult(result json.RawMessage) error {
+	if err := client.NotEmptyResultValidator(result); err != nil {
+		return err
+	}
+	if string(result) == `"0x0"` {
+		return errors.New("zero block number")
+	}
+	return nil
+}
+
 // GetLatestBlockNumberOf call eth_blockNumber
 func (b *Bridge) GetLatestBlockNumberOf(apiAddress string) (uint64, error) {
 	var result string
@@ -74,6 +94,83 @@ func (b *Bridge) GetBlockByNumber(number *big.Int) (*types.RPCBlock, error) {
 	return nil, err
 }
 
+// GetBaseFee get base fee of the latest block
+func (b *Bridge) GetBaseFee() (*big.Int, error) {
+	block, err := b.GetBlockByNumber(nil)
+	if err != nil {
+		return nil, err
+	}
+	if block.BaseFee == nil {
+		return nil, errors.New("block without base fee (pre-London chain)")
+	}
+	return block.BaseFee.ToInt(), nil
+}
+
+// GetBlockGasLimit get gas limit of the latest block
+func (b *Bridge) GetBlockGasLimit() (uint64, error) {
+	block, err := b.GetBlockByNumber(nil)
+	if err != nil {
+		return 0, err
+	}
+	if block.GasLimit == nil {
+		return 0, errors.New("block without gas limit")
+	}
+	return uint64(*block.GasLimit), nil
+}
+
+// GetFinalizedBlockNumber call eth_getBlockByNumber with "finalized" tag
+func (b *Bridge) GetFinalizedBlockNumber() (uint64, error) {
+	return b.getBlockNumberByTag("finalized")
+}
+
+// GetSafeBlockNumber call eth_getBlockByNumber with "safe" tag
+func (b *Bridge) GetSafeBlockNumber() (uint64, error) {
+	return b.getBlockNumberByTag("safe")
+}
+
+func (b *Bridge) getBlockNumberByTag(tag string) (uint64, error) {
+	gateway := b.GatewayConfig
+	var result *types.RPCBlock
+	var err error
+	for _, apiAddress := range gateway.APIAddress {
+		url := apiAddress
+		err = client.RPCPost(&result, url, "eth_getBlockByNumber", tag, false)
+		if err == nil && result != nil {
+			break
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+	if result == nil || result.Number == nil {
+		return 0, fmt.Errorf("%v block not found", tag)
+	}
+	return result.Number.ToInt().Uint64(), nil
+}
+
+// GetBlockTimestamp get block timestamp by block hash or block number
+func (b *Bridge) GetBlockTimestamp(blockHashOrNumber string) (time.Time, error) {
+	var block *types.RPCBlock
+	var err error
+	if len(blockHashOrNumber) == 2*common.HashLength+2 && common.HasHexPrefix(blockHashOrNumber) {
+		block, err = b.GetBlockByHash(blockHashOrNumber)
+	} else {
+		var number *big.Int
+		number, err = common.GetBigIntFromStr(blockHashOrNumber)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("wrong block hash or number '%v'", blockHashOrNumber)
+		}
+		block, err = b.GetBlockByNumber(number)
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	if block.Time == nil {
+		return time.Time{}, errors.New("block without timestamp")
+	}
+	return time.Unix(block.Time.ToInt().Int64(), 0), nil
+}
+
 // GetTransactionByHash call eth_getTransactionByHash
 func (b *Bridge) GetTransactionByHash(txHash string) (*types.RPCTransaction, error) {
 	gateway := b.GatewayConfig
@@ -123,6 +220,30 @@ func (b *Bridge) GetTransactionReceipt(txHash string) (*types.RPCTxReceipt, erro
 	return nil, err
 }
 
+// GetTxInclusion get the block and index which the tx is included in
+// return tokens.ErrTxNotFound if the tx is not found or still pending
+func (b *Bridge) GetTxInclusion(txHash string) (blockNumber uint64, blockHash string, index uint, err error) {
+	gateway := b.GatewayConfig
+	var result *types.RPCTxReceipt
+	for _, apiAddress := range gateway.APIAddress {
+		url := apiAddress
+		err = client.RPCPost(&result, url, "eth_getTransactionReceipt", txHash)
+		if err == nil && result != nil {
+			break
+		}
+	}
+	if result == nil {
+		if err == nil {
+			err = tokens.ErrTxNotFound
+		}
+		return 0, "", 0, err
+	}
+	if result.BlockNumber == nil || result.BlockHash == nil || result.TxIndex == nil {
+		return 0, "", 0, tokens.ErrTxNotFound
+	}
+	return result.BlockNumber.ToInt().Uint64(), result.BlockHash.Hex(), uint(*result.TxIndex), nil
+}
+
 // GetContractLogs get contract logs
 func (b *Bridge) GetContractLogs(contractAddresses []common.Address, logTopics [][]common.Hash, blockHeight uint64) ([]*types.RPCLog, error) {
 	height := new(big.Int).SetUint64(blockHeight)
@@ -153,15 +274,20 @@ func (b *Bridge) GetLogs(filterQuery *types.FilterQuery) (result []*types.RPCLog
 	return nil, err
 }
 
-// GetPoolNonce call eth_getTransactionCount
-func (b *Bridge) GetPoolNonce(address, height string) (uint64, error) {
+// GetPoolNonce call eth_getTransactionCount with "pending" tag
+func (b *Bridge) GetPoolNonce(address string) (uint64, error) {
+	return b.GetNonce(address, "pending")
+}
+
+// GetNonce call eth_getTransactionCount with block tag
+func (b *Bridge) GetNonce(address, blockTag string) (uint64, error) {
 	account := common.HexToAddress(address)
 	gateway := b.GatewayConfig
 	var result hexutil.Uint64
 	var err error
 	for _, apiAddress := range gateway.APIAddress {
 		url := apiAddress
-		err = client.RPCPost(&result, url, "eth_getTransactionCount", account, height)
+		err = client.RPCPost(&result, url, "eth_getTransactionCount", account, blockTag)
 		if err == nil {
 			return uint64(result), nil
 		}
@@ -169,6 +295,31 @@ func (b *Bridge) GetPoolNonce(address, height string) (uint64, error) {
 	return 0, err
 }
 
+// GetMaxNonceOfAllGateways call eth_getTransactionCount with block tag
+// on all gateways and return the max nonce of those responded
+func (b *Bridge) GetMaxNonceOfAllGateways(address, blockTag string) (maxNonce uint64, err error) {
+	account := common.HexToAddress(address)
+	gateway := b.GatewayConfig
+	success := false
+	for _, apiAddress := range gateway.APIAddress {
+		url := apiAddress
+		var result hexutil.Uint64
+		errf := client.RPCPost(&result, url, "eth_getTransactionCount", account, blockTag)
+		if errf != nil {
+			err = errf
+			continue
+		}
+		success = true
+		if uint64(result) > maxNonce {
+			maxNonce = uint64(result)
+		}
+	}
+	if !success {
+		return 0, err
+	}
+	return maxNonce, nil
+}
+
 // SuggestPrice call eth_gasPrice
 func (b *Bridge) SuggestPrice() (*big.Int, error) {
 	gateway := b.GatewayConfig
@@ -184,23 +335,66 @@ func (b *Bridge) SuggestPrice() (*big.Int, error) {
 	return nil, err
 }
 
+// GetBlobBaseFee call eth_blobBaseFee (fail on chains without blob support)
+func (b *Bridge) GetBlobBaseFee() (*big.Int, error) {
+	gateway := b.GatewayConfig
+	var result hexutil.Big
+	var err error
+	for _, apiAddress := range gateway.APIAddress {
+		url := apiAddress
+		err = client.RPCPost(&result, url, "eth_blobBaseFee")
+		if err == nil {
+			return result.ToInt(), nil
+		}
+	}
+	return nil, err
+}
+
+// FeeHistory call eth_feeHistory
+func (b *Bridge) FeeHistory(blockCount int, rewardPercentiles []float64) (*types.RPCFeeHistory, error) {
+	gateway := b.GatewayConfig
+	var result *types.RPCFeeHistory
+	var err error
+	for _, apiAddress := range gateway.APIAddress {
+		url := apiAddress
+		err = client.RPCPost(&result, url, "eth_feeHistory", hexutil.Uint64(blockCount), "latest", rewardPercentiles)
+		if err == nil && result != nil {
+			return result, nil
+		}
+	}
+	if result == nil && err == nil {
+		return nil, errors.New("fee history not found")
+	}
+	return nil, err
+}
+
 // SendSignedTransaction call eth_sendRawTransaction
-func (b *Bridge) SendSignedTransaction(tx *types.Transaction) error {
-	data, err := rlp.EncodeToBytes(tx)
+func (b *Bridge) SendSignedTransaction(tx *types.Transaction) (txHash string, err error) {
+	data, err := tx.MarshalBinary()
 	if err != nil {
-		return err
+		return "", err
 	}
 	hexData := common.ToHex(data)
+	return b.SendRawTransactionHex(hexData)
+}
+
+// SendRawTransactionHex call eth_sendRawTransaction with hex encoded signed tx
+func (b *Bridge) SendRawTransactionHex(rawHex string) (txHash string, err error) {
+	if _, err = hexutil.Decode(rawHex); err != nil {
+		return "", fmt.Errorf("wrong raw tx hex: %v", err)
+	}
+	release := tokens.AcquireSendSlot()
+	defer release()
 	gateway := b.GatewayConfig
-	var result interface{}
-	for _, apiAddress := range gateway.APIAddress {
+	var result string
+	for _, apiAddress := range gateway.GetWriteAPIAddress() {
 		url := apiAddress
-		err = client.RPCPost(&result, url, "eth_sendRawTransaction", hexData)
+		err = client.RPCPost(&result, url, "eth_sendRawTransaction", rawHex)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 	}
-	return err
+	return "", err
 }
 
 // ChainID call eth_chainId
@@ -245,7 +439,7 @@ func (b *Bridge) GetCode(contract string) ([]byte, error) {
 	var err error
 	for _, apiAddress := range gateway.APIAddress {
 		url := apiAddress
-		err = client.RPCPost(&result, url, "eth_getCode", contract, "latest")
+		err = client.RPCPost(&result, url, "eth_getCode", contract, gateway.GetBlockTag("latest"))
 		if err == nil {
 			return []byte(result), nil
 		}
@@ -253,6 +447,24 @@ func (b *Bridge) GetCode(contract string) ([]byte, error) {
 	return nil, err
 }
 
+// GetStorageAt call eth_getStorageAt (use gateway default block tag if blockTag is empty)
+func (b *Bridge) GetStorageAt(address string, slot common.Hash, blockTag string) (common.Hash, error) {
+	gateway := b.GatewayConfig
+	if blockTag == "" {
+		blockTag = gateway.GetBlockTag("latest")
+	}
+	var result common.Hash
+	var err error
+	for _, apiAddress := range gateway.APIAddress {
+		url := apiAddress
+		err = client.RPCPost(&result, url, "eth_getStorageAt", address, slot, blockTag)
+		if err == nil {
+			return result, nil
+		}
+	}
+	return common.Hash{}, err
+}
+
 // CallContract call eth_call
 func (b *Bridge) CallContract(contract string, data hexutil.Bytes, blockNumber string) (string, error) {
 	reqArgs := map[string]interface{}{
@@ -279,10 +491,23 @@ func (b *Bridge) GetBalance(account string) (*big.Int, error) {
 	var err error
 	for _, apiAddress := range gateway.APIAddress {
 		url := apiAddress
-		err = client.RPCPost(&result, url, "eth_getBalance", account, "latest")
+		err = client.RPCPost(&result, url, "eth_getBalance", account, gateway.GetBlockTag("latest"))
 		if err == nil {
 			return result.ToInt(), nil
 		}
 	}
 	return nil, err
 }
+
+// CallRPC call custom json rpc method with configed gateway
+func (b *Bridge) CallRPC(result interface{}, method string, args ...interface{}) (err error) {
+	gateway := b.GatewayConfig
+	for _, apiAddress := range gateway.APIAddress {
+		url := apiAddress
+		err = client.RPCPost(result, url, method, args...)
+		if err == nil {
+			return nil
+		}
+	}
+	return err
+}
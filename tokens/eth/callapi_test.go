@@ -0,0 +1,69 @@
+package eth
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+// newNonceTestServer return a json rpc server which answers
+// eth_getTransactionCount with the nonce configed for the block tag
+func newNonceTestServer(t *testing.T, nonces map[string]uint64) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     int           `json:"id"`
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request failed: %v", err)
+			return
+		}
+		if req.Method != "eth_getTransactionCount" || len(req.Params) != 2 {
+			t.Errorf("unexpected request %v %v", req.Method, req.Params)
+			return
+		}
+		tag, _ := req.Params[1].(string)
+		nonce, exist := nonces[tag]
+		if !exist {
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"error":{"code":-32602,"message":"unknown block tag %v"}}`, req.ID, tag)
+			return
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":"0x%x"}`, req.ID, nonce)
+	}))
+}
+
+func TestGetNonce(t *testing.T) {
+	server := newNonceTestServer(t, map[string]uint64{"latest": 5, "pending": 8})
+	defer server.Close()
+
+	b := NewCrossChainBridge(true)
+	b.GatewayConfig = &tokens.GatewayConfig{APIAddress: []string{server.URL}}
+
+	address := "0x0000000000000000000000000000000000000001"
+	for tag, want := range map[string]uint64{"latest": 5, "pending": 8} {
+		nonce, err := b.GetNonce(address, tag)
+		if err != nil {
+			t.Fatalf("GetNonce with tag %v failed: %v", tag, err)
+		}
+		if nonce != want {
+			t.Errorf("GetNonce with tag %v: want %v, got %v", tag, want, nonce)
+		}
+	}
+
+	nonce, err := b.GetPoolNonce(address)
+	if err != nil {
+		t.Fatalf("GetPoolNonce failed: %v", err)
+	}
+	if nonce != 8 {
+		t.Errorf("GetPoolNonce should use pending tag: want 8, got %v", nonce)
+	}
+
+	if _, err = b.GetNonce(address, "safe"); err == nil {
+		t.Errorf("GetNonce with unknown tag should fail")
+	}
+}
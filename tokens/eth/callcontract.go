@@ -1,12 +1,15 @@
 package eth
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"math/big"
 	"strings"
 
 	"github.com/anyswap/CrossChain-Bridge/common"
 	"github.com/anyswap/CrossChain-Bridge/common/hexutil"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
 )
 
 // token types (should be all upper case)
@@ -14,11 +17,14 @@ const (
 	ERC20TokenType = "ERC20"
 )
 
-// GetErc20TotalSupply get erc20 total supply of address
+// first 4 bytes of `Keccak256Hash([]byte("swapinExisted(bytes32)"))`
+var defSwapinExistedFuncHash = common.FromHex("0x53265288")
+
+// GetErc20TotalSupply get erc20 total supply of contract (call `totalSupply()`)
 func (b *Bridge) GetErc20TotalSupply(contract string) (*big.Int, error) {
 	data := make(hexutil.Bytes, 4)
 	copy(data[:4], erc20CodeParts["totalSupply"])
-	result, err := b.CallContract(contract, data, "latest")
+	result, err := b.CallContract(contract, data, b.GatewayConfig.GetBlockTag("latest"))
 	if err != nil {
 		return nil, err
 	}
@@ -30,7 +36,7 @@ func (b *Bridge) GetErc20Balance(contract, address string) (*big.Int, error) {
 	data := make(hexutil.Bytes, 36)
 	copy(data[:4], erc20CodeParts["balanceOf"])
 	copy(data[4:], common.HexToAddress(address).Hash().Bytes())
-	result, err := b.CallContract(contract, data, "latest")
+	result, err := b.CallContract(contract, data, b.GatewayConfig.GetBlockTag("latest"))
 	if err != nil {
 		return nil, err
 	}
@@ -41,7 +47,7 @@ func (b *Bridge) GetErc20Balance(contract, address string) (*big.Int, error) {
 func (b *Bridge) GetErc20Decimals(contract string) (uint8, error) {
 	data := make(hexutil.Bytes, 4)
 	copy(data[:4], erc20CodeParts["decimals"])
-	result, err := b.CallContract(contract, data, "latest")
+	result, err := b.CallContract(contract, data, b.GatewayConfig.GetBlockTag("latest"))
 	if err != nil {
 		return 0, err
 	}
@@ -49,6 +55,167 @@ func (b *Bridge) GetErc20Decimals(contract string) (uint8, error) {
 	return uint8(decimals), err
 }
 
+// GetErc20Symbol get erc20 symbol
+func (b *Bridge) GetErc20Symbol(contract string) (string, error) {
+	return b.getErc20StringField(contract, "symbol")
+}
+
+// GetErc20Name get erc20 name
+func (b *Bridge) GetErc20Name(contract string) (string, error) {
+	return b.getErc20StringField(contract, "name")
+}
+
+func (b *Bridge) getErc20StringField(contract, field string) (string, error) {
+	data := make(hexutil.Bytes, 4)
+	copy(data[:4], erc20CodeParts[field])
+	result, err := b.CallContract(contract, data, b.GatewayConfig.GetBlockTag("latest"))
+	if err != nil {
+		return "", err
+	}
+	return parseErc20StringResult(common.FromHex(result))
+}
+
+// parse abi encoded string, or bytes32 of non-standard tokens
+func parseErc20StringResult(data []byte) (string, error) {
+	dataLength := uint64(len(data))
+	if dataLength == 32 {
+		return string(bytes.TrimRight(data, "\x00")), nil
+	}
+	if dataLength < 64 || dataLength%32 != 0 {
+		return "", fmt.Errorf("wrong string result length %v", dataLength)
+	}
+	offset, overflow := common.GetUint64(data, 0, 32)
+	if overflow || dataLength < offset+32 {
+		return "", errors.New("wrong string result offset")
+	}
+	length, overflow := common.GetUint64(data, offset, 32)
+	if overflow || dataLength < offset+32+length {
+		return "", errors.New("wrong string result length")
+	}
+	return string(common.GetData(data, offset+32, length)), nil
+}
+
+// IsSwapinCompleted query swap contract whether swapin of txhash is completed
+func (b *Bridge) IsSwapinCompleted(pairID, txhash string) (bool, error) {
+	tokenCfg := b.GetTokenConfig(pairID)
+	if tokenCfg == nil {
+		return false, tokens.ErrUnknownPairID
+	}
+	funcHash := defSwapinExistedFuncHash
+	if tokenCfg.SwapinExistedFuncHash != "" {
+		funcHash = common.FromHex(tokenCfg.SwapinExistedFuncHash)
+	}
+	data := make(hexutil.Bytes, 36)
+	copy(data[:4], funcHash)
+	copy(data[4:], common.HexToHash(txhash).Bytes())
+	result, err := b.CallContract(tokenCfg.ContractAddress, data, b.GatewayConfig.GetBlockTag("latest"))
+	if err != nil {
+		return false, err
+	}
+	existed, err := common.GetBigIntFromStr(result)
+	if err != nil {
+		return false, err
+	}
+	return existed.Sign() != 0, nil
+}
+
+// IsBlacklisted query whether address is blacklisted on token,
+// call the blacklist view configured by 'BlacklistFuncHash' of the token
+func (b *Bridge) IsBlacklisted(token, address string) (bool, error) {
+	for _, tokenCfg := range b.getTokenConfigsOfContract(token) {
+		if tokenCfg.BlacklistFuncHash != "" {
+			return b.isBlacklistedByFuncHash(token, address, common.FromHex(tokenCfg.BlacklistFuncHash))
+		}
+	}
+	return false, nil
+}
+
+// get token configs of this side whose contract address is 'contract'
+func (b *Bridge) getTokenConfigsOfContract(contract string) (tokenCfgs []*tokens.TokenConfig) {
+	for _, pairCfg := range tokens.GetTokenPairsConfig() {
+		tokenCfg := pairCfg.DestToken
+		if b.IsSrc {
+			tokenCfg = pairCfg.SrcToken
+		}
+		if tokenCfg != nil && strings.EqualFold(tokenCfg.ContractAddress, contract) {
+			tokenCfgs = append(tokenCfgs, tokenCfg)
+		}
+	}
+	return tokenCfgs
+}
+
+// IsContractPaused query whether contract is paused,
+// call the paused view configured by 'PausedFuncHash' of the token
+func (b *Bridge) IsContractPaused(address string) (bool, error) {
+	for _, tokenCfg := range b.getTokenConfigsOfContract(address) {
+		if tokenCfg.PausedFuncHash != "" {
+			return b.isPausedByFuncHash(address, common.FromHex(tokenCfg.PausedFuncHash))
+		}
+	}
+	return false, nil
+}
+
+func (b *Bridge) isPausedByFuncHash(contract string, funcHash []byte) (bool, error) {
+	data := make(hexutil.Bytes, 4)
+	copy(data[:4], funcHash)
+	result, err := b.CallContract(contract, data, b.GatewayConfig.GetBlockTag("latest"))
+	if err != nil {
+		return false, err
+	}
+	paused, err := common.GetBigIntFromStr(result)
+	if err != nil {
+		return false, err
+	}
+	return paused.Sign() != 0, nil
+}
+
+func (b *Bridge) checkContractPaused(tokenCfg *tokens.TokenConfig, opts *buildOptions) error {
+	if tokenCfg.PausedFuncHash == "" || opts.offline {
+		return nil
+	}
+	paused, err := b.isPausedByFuncHash(tokenCfg.ContractAddress, common.FromHex(tokenCfg.PausedFuncHash))
+	if err != nil {
+		opts.logger.Warn("query contract paused state failed", "contract", tokenCfg.ContractAddress, "err", err)
+		return err
+	}
+	if paused {
+		opts.logger.Warn("contract is paused", "contract", tokenCfg.ContractAddress)
+		return tokens.ErrContractPaused
+	}
+	return nil
+}
+
+func (b *Bridge) isBlacklistedByFuncHash(token, address string, funcHash []byte) (bool, error) {
+	data := make(hexutil.Bytes, 36)
+	copy(data[:4], funcHash)
+	copy(data[4:], common.HexToAddress(address).Hash().Bytes())
+	result, err := b.CallContract(token, data, b.GatewayConfig.GetBlockTag("latest"))
+	if err != nil {
+		return false, err
+	}
+	blacklisted, err := common.GetBigIntFromStr(result)
+	if err != nil {
+		return false, err
+	}
+	return blacklisted.Sign() != 0, nil
+}
+
+func (b *Bridge) checkRecipientBlacklisted(token *tokens.TokenConfig, bind string, opts *buildOptions) error {
+	if token.BlacklistFuncHash == "" || opts.offline {
+		return nil
+	}
+	blacklisted, err := b.isBlacklistedByFuncHash(token.ContractAddress, bind, common.FromHex(token.BlacklistFuncHash))
+	if err != nil {
+		opts.logger.Warn("query recipient blacklist failed", "token", token.ContractAddress, "address", bind, "err", err)
+		return err
+	}
+	if blacklisted {
+		opts.logger.Warn("swap to blacklisted recipient", "token", token.ContractAddress, "address", bind)
+		return tokens.ErrRecipientBlacklisted
+	}
+	return nil
+}
+
 // GetTokenBalance api
 func (b *Bridge) GetTokenBalance(tokenType, tokenAddress, accountAddress string) (*big.Int, error) {
 	switch strings.ToUpper(tokenType) {
@@ -68,3 +235,42 @@ func (b *Bridge) GetTokenSupply(tokenType, tokenAddress string) (*big.Int, error
 		return nil, fmt.Errorf("[%v] can not get token supply of token with type '%v'", b.ChainConfig.BlockChain, tokenType)
 	}
 }
+
+var (
+	// first 4 bytes of `Keccak256Hash([]byte("mpc()"))`
+	mpcFuncHash = common.FromHex("0xf75c2664")
+	// first 4 bytes of `Keccak256Hash([]byte("owner()"))`
+	ownerFuncHash = common.FromHex("0x8da5cb5b")
+)
+
+// GetSwapContractOwner get the authorized caller of swap contract
+// by calling `mpc()`, and fallback to `owner()` if failed
+func (b *Bridge) GetSwapContractOwner(pairID string) (common.Address, error) {
+	tokenCfg := b.GetTokenConfig(pairID)
+	if tokenCfg == nil {
+		return common.Address{}, tokens.ErrUnknownPairID
+	}
+	if tokenCfg.ContractAddress == "" {
+		return common.Address{}, errors.New("swap contract address is not configed")
+	}
+	return b.getContractOwner(tokenCfg.ContractAddress)
+}
+
+func (b *Bridge) getContractOwner(contract string) (owner common.Address, err error) {
+	for _, funcHash := range [][]byte{mpcFuncHash, ownerFuncHash} {
+		data := make(hexutil.Bytes, 4)
+		copy(data[:4], funcHash)
+		var result string
+		result, err = b.CallContract(contract, data, b.GatewayConfig.GetBlockTag("latest"))
+		if err != nil {
+			continue
+		}
+		resultBytes := common.FromHex(result)
+		if len(resultBytes) != 32 {
+			err = fmt.Errorf("wrong result length %v of owner call", len(resultBytes))
+			continue
+		}
+		return common.BytesToAddress(resultBytes), nil
+	}
+	return common.Address{}, err
+}
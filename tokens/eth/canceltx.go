@@ -0,0 +1,154 @@
+package eth
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/types"
+)
+
+var (
+	// replacing tx must have at least this percentage higher gas price
+	minReplaceGasPricePercentage = uint64(10)
+
+	cancelTxGasLimit = uint64(21000)
+)
+
+// CancelTransaction cancel pending tx by sending zero value self transfer
+// with the same nonce and higher gas price (require dcrm address private key)
+func (b *Bridge) CancelTransaction(from string, nonce uint64) (txHash string, err error) {
+	tokenCfg := b.findTokenConfigOfDcrmAddress(from)
+	if tokenCfg == nil || tokenCfg.GetDcrmAddressPrivateKey() == nil {
+		return "", fmt.Errorf("can not find private key of address '%v'", from)
+	}
+
+	latestNonce, err := b.GetNonce(from, "latest")
+	if err != nil {
+		return "", err
+	}
+	if nonce < latestNonce {
+		return "", fmt.Errorf("nonce %v is already mined (latest nonce is %v)", nonce, latestNonce)
+	}
+
+	gasPrice, err := b.getReplaceGasPrice(from, nonce)
+	if err != nil {
+		return "", err
+	}
+
+	account := common.HexToAddress(from)
+	rawTx := types.NewTransaction(nonce, account, big.NewInt(0), cancelTxGasLimit, gasPrice, nil)
+	signedTx, txHash, err := b.SignTransactionWithPrivateKey(rawTx, tokenCfg.GetDcrmAddressPrivateKey())
+	if err != nil {
+		return "", err
+	}
+	_, err = b.SendTransaction(signedTx)
+	if err != nil {
+		return "", err
+	}
+	log.Info("cancel transaction success", "from", from, "nonce", nonce, "gasPrice", gasPrice, "txHash", txHash)
+	return txHash, nil
+}
+
+func (b *Bridge) findTokenConfigOfDcrmAddress(address string) *tokens.TokenConfig {
+	for _, pairID := range tokens.GetAllPairIDs() {
+		tokenCfg := b.GetTokenConfig(pairID)
+		if tokenCfg != nil && strings.EqualFold(tokenCfg.DcrmAddress, address) {
+			return tokenCfg
+		}
+	}
+	return nil
+}
+
+// getReplaceGasPrice get gas price to replace pending tx of 'from' with 'nonce'
+func (b *Bridge) getReplaceGasPrice(from string, nonce uint64) (*big.Int, error) {
+	gasPrice, err := b.getGasPrice()
+	if err != nil {
+		return nil, err
+	}
+	oldGasPrice := b.getPendingTxGasPrice(from, nonce)
+	if oldGasPrice != nil && oldGasPrice.Cmp(gasPrice) > 0 {
+		gasPrice = oldGasPrice
+	}
+	return bumpGasPrice(gasPrice, minReplaceGasPricePercentage), nil
+}
+
+func (b *Bridge) getPendingTxGasPrice(from string, nonce uint64) *big.Int {
+	txs, err := b.GetPendingTransactions()
+	if err != nil {
+		log.Debug("get pending transactions failed", "err", err)
+		return nil
+	}
+	for _, tx := range txs {
+		if tx.From == nil || tx.AccountNonce == nil || tx.Price == nil {
+			continue
+		}
+		if uint64(*tx.AccountNonce) == nonce && strings.EqualFold(tx.From.String(), from) {
+			return tx.Price.ToInt()
+		}
+	}
+	return nil
+}
+
+// GetPendingTxGasPrice get gas price of pending tx (max fee per gas for dynamic fee tx)
+// return tokens.ErrTxAlreadyMined if mined, or tokens.ErrTxNotFound if dropped
+func (b *Bridge) GetPendingTxGasPrice(txHash string) (*big.Int, error) {
+	tx, err := b.getPendingTx(txHash)
+	if err != nil {
+		return nil, err
+	}
+	if tx.GasFeeCap != nil {
+		return tx.GasFeeCap.ToInt(), nil
+	}
+	if tx.Price == nil {
+		return nil, fmt.Errorf("pending tx %v without gas price", txHash)
+	}
+	return tx.Price.ToInt(), nil
+}
+
+// GetPendingTxFeeCaps get max fee and max priority fee per gas of pending dynamic fee tx
+func (b *Bridge) GetPendingTxFeeCaps(txHash string) (gasFeeCap, gasTipCap *big.Int, err error) {
+	tx, err := b.getPendingTx(txHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	if tx.GasFeeCap == nil || tx.GasTipCap == nil {
+		return nil, nil, fmt.Errorf("pending tx %v is not dynamic fee tx", txHash)
+	}
+	return tx.GasFeeCap.ToInt(), tx.GasTipCap.ToInt(), nil
+}
+
+// GetMinReplaceGasPrice get the minimum gas price to replace pending tx
+func (b *Bridge) GetMinReplaceGasPrice(txHash string) (*big.Int, error) {
+	gasPrice, err := b.GetPendingTxGasPrice(txHash)
+	if err != nil {
+		return nil, err
+	}
+	return bumpGasPrice(gasPrice, minReplaceGasPricePercentage), nil
+}
+
+func (b *Bridge) getPendingTx(txHash string) (*types.RPCTransaction, error) {
+	tx, err := b.GetTransactionByHash(txHash)
+	if err != nil {
+		if err.Error() == tokens.ErrTxNotFound.Error() {
+			return nil, tokens.ErrTxNotFound
+		}
+		return nil, err
+	}
+	if tx.BlockNumber != nil && tx.BlockNumber.ToInt().Sign() > 0 {
+		return nil, tokens.ErrTxAlreadyMined
+	}
+	return tx, nil
+}
+
+func bumpGasPrice(gasPrice *big.Int, percent uint64) *big.Int {
+	bumped := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(100+percent))
+	bumped.Div(bumped, big.NewInt(100))
+	if bumped.Cmp(gasPrice) <= 0 {
+		bumped.Add(gasPrice, big.NewInt(1))
+	}
+	return bumped
+}
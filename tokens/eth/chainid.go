@@ -0,0 +1,74 @@
+package eth
+
+import (
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+// GetExpectedChainID get configed expected chain id (nil if not configed)
+func (b *Bridge) GetExpectedChainID() *big.Int {
+	if b.ChainConfig == nil || b.ChainConfig.ChainID == "" {
+		return nil
+	}
+	chainID, ok := new(big.Int).SetString(b.ChainConfig.ChainID, 0)
+	if !ok {
+		return nil
+	}
+	return chainID
+}
+
+// AssertExpectedChainID exit if gateway chain id is not the configed expected one
+func (b *Bridge) AssertExpectedChainID() {
+	for {
+		err := b.CheckChainID()
+		if err == nil {
+			return
+		}
+		if errors.Is(err, tokens.ErrWrongChain) {
+			log.Fatal("gateway chain id mismatch", "blockChain", b.ChainConfig.BlockChain, "expected", b.ChainConfig.ChainID)
+		}
+		log.Error("check gateway chain id failed", "err", err)
+		time.Sleep(3 * time.Second)
+	}
+}
+
+// CheckChainID check gateway chain id is the configed expected one,
+// building and sending txs are halted with ErrWrongChain if mismatch
+func (b *Bridge) CheckChainID() error {
+	expected := b.GetExpectedChainID()
+	if expected == nil {
+		return nil
+	}
+	actual, err := b.getGatewayChainID()
+	if err != nil {
+		return err
+	}
+	if actual.Cmp(expected) != 0 {
+		log.Error("gateway chain id mismatch, halt building and sending txs", "blockChain", b.ChainConfig.BlockChain, "expected", expected, "actual", actual)
+		atomic.StoreInt32(&b.wrongChain, 1)
+		return tokens.ErrWrongChain
+	}
+	if atomic.SwapInt32(&b.wrongChain, 0) != 0 {
+		log.Info("gateway chain id recovered", "blockChain", b.ChainConfig.BlockChain, "chainID", actual)
+	}
+	return nil
+}
+
+// IsWrongChain is gateway chain id mismatch with the expected one
+func (b *Bridge) IsWrongChain() bool {
+	return atomic.LoadInt32(&b.wrongChain) != 0
+}
+
+// call NetworkID instead if ChainID return 0x0 wrongly
+func (b *Bridge) getGatewayChainID() (*big.Int, error) {
+	chainID, err := b.ChainID()
+	if err == nil && chainID.Sign() != 0 {
+		return chainID, nil
+	}
+	return b.NetworkID()
+}
@@ -0,0 +1,73 @@
+package eth
+
+import (
+	"math/big"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+const (
+	defaultCongestionMediumPercent = 125
+	defaultCongestionHighPercent   = 200
+)
+
+// GetCongestionLevel get network congestion level by comparing
+// the next block base fee to the average base fee of recent blocks
+func (b *Bridge) GetCongestionLevel() (tokens.CongestionLevel, error) {
+	current, average, err := b.getCurrentAndAverageBaseFee()
+	if err != nil {
+		return tokens.CongestionLow, err
+	}
+	if average.Sign() <= 0 {
+		return tokens.CongestionLow, nil
+	}
+	mediumPercent := b.ChainConfig.CongestionMediumPercent
+	if mediumPercent == 0 {
+		mediumPercent = defaultCongestionMediumPercent
+	}
+	highPercent := b.ChainConfig.CongestionHighPercent
+	if highPercent == 0 {
+		highPercent = defaultCongestionHighPercent
+	}
+	percent := new(big.Int).Mul(current, big.NewInt(100))
+	percent.Div(percent, average)
+
+	level := tokens.CongestionLow
+	switch {
+	case percent.Cmp(new(big.Int).SetUint64(highPercent)) >= 0:
+		level = tokens.CongestionHigh
+	case percent.Cmp(new(big.Int).SetUint64(mediumPercent)) >= 0:
+		level = tokens.CongestionMedium
+	}
+	log.Trace("get congestion level", "level", level, "current", current, "average", average, "percent", percent)
+	return level, nil
+}
+
+// getCurrentAndAverageBaseFee current is the base fee of the next block
+func (b *Bridge) getCurrentAndAverageBaseFee() (current, average *big.Int, err error) {
+	feeHistory, err := b.FeeHistory(feeHistoryBlockCount, []float64{})
+	if err != nil {
+		return nil, nil, err
+	}
+	count := len(feeHistory.BaseFee)
+	if count < 2 || feeHistory.BaseFee[count-1] == nil {
+		return nil, nil, errNoFeeHistory
+	}
+	current = feeHistory.BaseFee[count-1].ToInt()
+
+	sum := big.NewInt(0)
+	num := int64(0)
+	for _, baseFee := range feeHistory.BaseFee[:count-1] {
+		if baseFee == nil {
+			continue
+		}
+		sum.Add(sum, baseFee.ToInt())
+		num++
+	}
+	if num == 0 {
+		return nil, nil, errNoFeeHistory
+	}
+	average = sum.Div(sum, big.NewInt(num))
+	return current, average, nil
+}
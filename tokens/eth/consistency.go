@@ -0,0 +1,69 @@
+package eth
+
+import (
+	"errors"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/rpc/client"
+	"github.com/anyswap/CrossChain-Bridge/types"
+)
+
+// TxAndReceiptGetter get tx and receipt from one gateway
+// (receipt is nil if not found)
+type TxAndReceiptGetter func(url, txHash string) (*types.RPCTransaction, *types.RPCTxReceipt, error)
+
+// GetTransactionAndReceiptConsistent get tx and receipt and check their consistency
+func (b *Bridge) GetTransactionAndReceiptConsistent(txHash string) (tx *types.RPCTransaction, receipt *types.RPCTxReceipt, consistent bool, err error) {
+	return b.GetTransactionAndReceiptConsistentBy(txHash, getTxAndReceiptSeparately)
+}
+
+// GetTransactionAndReceiptConsistentBy get tx and receipt from the same gateway,
+// and retry against another gateway if they are inconsistent
+// (eg. load-balanced backends of one gateway are not synced)
+func (b *Bridge) GetTransactionAndReceiptConsistentBy(txHash string, getter TxAndReceiptGetter) (tx *types.RPCTransaction, receipt *types.RPCTxReceipt, consistent bool, err error) {
+	var found bool
+	for _, url := range b.GatewayConfig.APIAddress {
+		rtx, rreceipt, rerr := getter(url, txHash)
+		if rerr != nil || rtx == nil {
+			err = rerr
+			continue
+		}
+		tx, receipt, found = rtx, rreceipt, true
+		consistent = isTxAndReceiptConsistent(tx, receipt)
+		if consistent {
+			return tx, receipt, true, nil
+		}
+		log.Warn("tx and receipt are inconsistent", "txHash", txHash, "url", url)
+	}
+	if found {
+		return tx, receipt, false, nil
+	}
+	if err == nil {
+		err = errors.New("tx not found")
+	}
+	return nil, nil, false, err
+}
+
+// isTxAndReceiptConsistent pending tx should have no receipt,
+// and mined tx should have receipt in the same block.
+func isTxAndReceiptConsistent(tx *types.RPCTransaction, receipt *types.RPCTxReceipt) bool {
+	if tx.BlockHash == nil || tx.BlockNumber == nil {
+		return receipt == nil
+	}
+	if receipt == nil || receipt.BlockHash == nil {
+		return false
+	}
+	return *receipt.BlockHash == *tx.BlockHash
+}
+
+func getTxAndReceiptSeparately(url, txHash string) (tx *types.RPCTransaction, receipt *types.RPCTxReceipt, err error) {
+	err = client.RPCPost(&tx, url, "eth_getTransactionByHash", txHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = client.RPCPost(&receipt, url, "eth_getTransactionReceipt", txHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, receipt, nil
+}
@@ -0,0 +1,36 @@
+package eth
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+// BuildContractCallTx build tx calling arbitrary method of contract 'to'
+// (eg. admin operations like pausing token or updating mpc of swap contract).
+// params are packed by 'PackDataWithFuncHash', only allowed with 'NoSwapType'.
+func (b *Bridge) BuildContractCallTx(args *tokens.BuildTxArgs, to string, funcSelector [4]byte, params ...interface{}) (rawTx interface{}, err error) {
+	if args.SwapType != tokens.NoSwapType {
+		return nil, errors.New("build contract call tx require no swap type")
+	}
+	if b.IsWrongChain() {
+		return nil, tokens.ErrWrongChain
+	}
+	if !b.IsValidAddress(args.From) {
+		return nil, fmt.Errorf("invalid sender address '%v'", args.From)
+	}
+	if !b.IsValidAddress(to) {
+		return nil, fmt.Errorf("invalid contract address '%v'", to)
+	}
+	input := PackDataWithFuncHash(funcSelector[:], params...)
+	args.To = to
+	args.Input = &input
+
+	opts := &buildOptions{logger: newBuildLogger(args.PairID)}
+	extra, err := b.setDefaults(args)
+	if err != nil {
+		return nil, err
+	}
+	return b.buildTx(args, extra, input, opts)
+}
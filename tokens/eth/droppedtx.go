@@ -0,0 +1,81 @@
+package eth
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/rpc/client"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+// CheckTxDropped check whether sent tx is dropped from mempool.
+// return tokens.ErrTxDropped if the tx is neither mined nor in the txpools of gateways,
+// and its nonce has been consumed by a different tx (so it can never be mined).
+// return nil if the tx is mined, pending, or its nonce is not consumed yet (can be resubmitted).
+func (b *Bridge) CheckTxDropped(txHash, from string, nonce uint64) error {
+	if receipt, err := b.GetTransactionReceipt(txHash); err == nil && receipt != nil {
+		return nil
+	}
+	inPool, err := b.isTxInTxPool(txHash, from, nonce)
+	switch {
+	case err == nil:
+		if inPool {
+			return nil
+		}
+	case err == tokens.ErrTxPoolNotSupported:
+		// fallback to query tx by hash, which also finds pending tx
+		if tx, errt := b.GetTransaction(txHash); errt == nil && tx != nil {
+			return nil
+		}
+	default:
+		return err
+	}
+	latestNonce, err := b.GetNonce(from, "latest")
+	if err != nil {
+		return err
+	}
+	if latestNonce > nonce {
+		log.Warn("tx is dropped and its nonce is consumed", "txHash", txHash, "from", from, "nonce", nonce, "latestNonce", latestNonce)
+		return tokens.ErrTxDropped
+	}
+	return nil
+}
+
+// isTxInTxPool check whether tx is in the txpool of any gateway by `txpool_content`
+func (b *Bridge) isTxInTxPool(txHash, from string, nonce uint64) (bool, error) {
+	gateway := b.GatewayConfig
+	nonceStr := strconv.FormatUint(nonce, 10)
+	var err error
+	unsupported := true
+	succeed := false
+	for _, apiAddress := range gateway.APIAddress {
+		url := apiAddress
+		var result txPoolContent
+		err = client.RPCPost(&result, url, "txpool_content")
+		if err != nil {
+			if !isMethodNotSupportedError(err) {
+				unsupported = false
+			}
+			continue
+		}
+		succeed = true
+		for _, status := range []string{"pending", "queued"} {
+			for addr, txs := range result[status] {
+				if !strings.EqualFold(addr, from) {
+					continue
+				}
+				if tx := txs[nonceStr]; tx != nil && tx.Hash != nil && strings.EqualFold(tx.Hash.Hex(), txHash) {
+					return true, nil
+				}
+			}
+		}
+	}
+	if succeed {
+		return false, nil
+	}
+	if unsupported && err != nil {
+		return false, tokens.ErrTxPoolNotSupported
+	}
+	return false, err
+}
@@ -0,0 +1,170 @@
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/types"
+)
+
+const (
+	feeHistoryBlockCount        = 20
+	defaultFeeHistoryPercentile = 50
+	defaultBaseFeeMarginPercent = 13
+)
+
+var errNoFeeHistory = errors.New("no fee history")
+
+// isDynamicFeeTx build dynamic fee tx by the 'TxType' of pair
+func (b *Bridge) isDynamicFeeTx(pairID string) bool {
+	if tokenCfg := b.GetTokenConfig(pairID); tokenCfg != nil {
+		switch tokenCfg.TxType {
+		case tokens.TxTypeLegacy:
+			return false
+		case tokens.TxTypeDynamicFee, tokens.TxTypeBlob:
+			return true
+		}
+	}
+	return b.ChainConfig.EnableDynamicFeeTx
+}
+
+// verifyTxType reject dynamic fee tx type on pre-London chain
+func (b *Bridge) verifyTxType(tokenCfg *tokens.TokenConfig) error {
+	if tokenCfg.TxType == tokens.TxTypeBlob {
+		return b.verifyBlobTxType(tokenCfg)
+	}
+	if tokenCfg.TxType != tokens.TxTypeDynamicFee {
+		return nil
+	}
+	if _, ok := b.Signer.(types.LondonSigner); !ok {
+		return errors.New("'TxType' DynamicFee require london signer")
+	}
+	if _, err := b.GetBaseFee(); err != nil {
+		return fmt.Errorf("'TxType' DynamicFee is not supported by chain: %v", err)
+	}
+	return nil
+}
+
+func (b *Bridge) setDynamicFeeDefaults(args *tokens.BuildTxArgs, extra *tokens.EthExtraArgs) error {
+	if extra.GasTipCap != nil && extra.GasFeeCap != nil {
+		// keep the caller supplied caps untouched (offline building,
+		// or rebuilding by accept nodes which must reproduce the same msgHash)
+		return nil
+	}
+	baseFee, tip, err := b.getBaseFeeAndTip()
+	if err != nil {
+		return err
+	}
+	if extra.GasTipCap == nil {
+		if args.SwapType != tokens.NoSwapType {
+			tokenCfg := b.GetTokenConfig(args.PairID)
+			if tokenCfg == nil {
+				return tokens.ErrUnknownPairID
+			}
+			addPercent := tokenCfg.GetPlusGasPricePercentage(args.SwapType)
+			if addPercent > 0 {
+				tip.Mul(tip, big.NewInt(int64(100+addPercent)))
+				tip.Div(tip, big.NewInt(100))
+			}
+		}
+		extra.GasTipCap = tip
+	}
+	if extra.GasFeeCap == nil {
+		// maxFeePerGas = baseFee * 2 + tip (base fee is bumped if it is rising sharply)
+		if trendPercent := b.getBaseFeeTrendPercentage(); trendPercent > 0 {
+			baseFee = new(big.Int).Mul(baseFee, new(big.Int).SetUint64(100+trendPercent))
+			baseFee.Div(baseFee, big.NewInt(100))
+		}
+		feeCap := new(big.Int).Mul(baseFee, big.NewInt(2))
+		extra.GasFeeCap = feeCap.Add(feeCap, extra.GasTipCap)
+	}
+	if extra.GasFeeCap.Cmp(extra.GasTipCap) < 0 {
+		return errors.New("max fee per gas is lower than max priority fee per gas")
+	}
+	return b.ensureFeeCapAboveBaseFee(extra)
+}
+
+// ensureFeeCapAboveBaseFee raise max fee per gas to current base fee plus margin and tip,
+// as tx with max fee per gas below base fee is rejected by mempool
+// (eg. the fallback gas price may be lower than base fee during rapid changes)
+func (b *Bridge) ensureFeeCapAboveBaseFee(extra *tokens.EthExtraArgs) error {
+	if b.testStateProvider != nil {
+		return nil
+	}
+	baseFee, err := b.GetBaseFee()
+	if err != nil {
+		log.Warn("get base fee failed, skip checking max fee per gas", "err", err)
+		return nil
+	}
+	margin := b.ChainConfig.BaseFeeMarginPercent
+	if margin == 0 {
+		margin = defaultBaseFeeMarginPercent
+	}
+	minFeeCap := new(big.Int).Mul(baseFee, new(big.Int).SetUint64(100+margin))
+	minFeeCap.Div(minFeeCap, big.NewInt(100))
+	if extra.GasFeeCap.Cmp(minFeeCap) < 0 {
+		newFeeCap := minFeeCap.Add(minFeeCap, extra.GasTipCap)
+		log.Info("raise max fee per gas above base fee", "baseFee", baseFee, "oldFeeCap", extra.GasFeeCap, "newFeeCap", newFeeCap)
+		extra.GasFeeCap = newFeeCap
+	}
+	return nil
+}
+
+// getBaseFeeAndTip get next block base fee and tip from fee history,
+// and fallback to gas price and configed flat tip if fee history is unavailable
+func (b *Bridge) getBaseFeeAndTip() (baseFee, tip *big.Int, err error) {
+	percentile := b.ChainConfig.FeeHistoryPercentile
+	if percentile == 0 {
+		percentile = defaultFeeHistoryPercentile
+	}
+	baseFee, tip, err = b.calcBaseFeeAndTip(percentile)
+	if err == nil {
+		return baseFee, tip, nil
+	}
+	log.Warn("get fee history failed, use flat tip instead", "err", err)
+	baseFee, err = b.GetBaseFee()
+	if err != nil {
+		log.Warn("get base fee failed, use gas price instead", "err", err)
+		baseFee, err = b.getGasPrice()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	tip = b.ChainConfig.GetDefaultGasTipCap()
+	return baseFee, tip, nil
+}
+
+// calcBaseFeeAndTip tip is the average of rewards at percentile of recent blocks
+func (b *Bridge) calcBaseFeeAndTip(percentile float64) (baseFee, tip *big.Int, err error) {
+	feeHistory, err := b.FeeHistory(feeHistoryBlockCount, []float64{percentile})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(feeHistory.BaseFee) == 0 || len(feeHistory.Reward) == 0 {
+		return nil, nil, errNoFeeHistory
+	}
+	// the last one is the base fee of the next block
+	lastBaseFee := feeHistory.BaseFee[len(feeHistory.BaseFee)-1]
+	if lastBaseFee == nil {
+		return nil, nil, errNoFeeHistory
+	}
+	baseFee = new(big.Int).Set(lastBaseFee.ToInt())
+
+	tip = big.NewInt(0)
+	count := int64(0)
+	for _, rewards := range feeHistory.Reward {
+		if len(rewards) == 0 || rewards[0] == nil {
+			continue
+		}
+		tip.Add(tip, rewards[0].ToInt())
+		count++
+	}
+	if count == 0 {
+		return nil, nil, errNoFeeHistory
+	}
+	tip.Div(tip, big.NewInt(count))
+	return baseFee, tip, nil
+}
@@ -0,0 +1,97 @@
+package eth
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+)
+
+var (
+	// first 4 bytes of `Keccak256Hash([]byte("resolver(bytes32)"))`
+	ensResolverFuncHash = common.FromHex("0x0178b8bf")
+	// first 4 bytes of `Keccak256Hash([]byte("addr(bytes32)"))`
+	ensAddrFuncHash = common.FromHex("0x3b3b57de")
+
+	ensCache         = make(map[string]*ensCacheItem)
+	ensCacheLock     sync.Mutex
+	ensCacheLifetime = 5 * time.Minute
+)
+
+type ensCacheItem struct {
+	address  string
+	expireAt time.Time
+}
+
+// IsEnsName is ens name (eg. 'alice.eth')
+func IsEnsName(name string) bool {
+	return len(name) > 4 && strings.HasSuffix(strings.ToLower(name), ".eth")
+}
+
+// EnsNameHash calc ens namehash of name
+func EnsNameHash(name string) common.Hash {
+	var node common.Hash
+	if name == "" {
+		return node
+	}
+	labels := strings.Split(strings.ToLower(name), ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		labelHash := common.Keccak256Hash([]byte(labels[i]))
+		node = common.Keccak256Hash(node.Bytes(), labelHash.Bytes())
+	}
+	return node
+}
+
+func (b *Bridge) isEnsEnabled() bool {
+	return b.ChainConfig != nil && b.ChainConfig.EnsRegistry != ""
+}
+
+// ResolveEnsName resolve ens name to address through configed ens registry
+func (b *Bridge) ResolveEnsName(name string) (string, error) {
+	if !b.isEnsEnabled() {
+		return "", errors.New("ens registry is not configed")
+	}
+	key := strings.ToLower(name)
+
+	ensCacheLock.Lock()
+	item, exist := ensCache[key]
+	ensCacheLock.Unlock()
+	if exist && time.Now().Before(item.expireAt) {
+		return item.address, nil
+	}
+
+	node := EnsNameHash(name)
+	resolver, err := b.callEnsAddress(b.ChainConfig.EnsRegistry, ensResolverFuncHash, node)
+	if err != nil {
+		return "", err
+	}
+	if resolver == (common.Address{}) {
+		return "", errors.New("ens resolver not found")
+	}
+	address, err := b.callEnsAddress(resolver.String(), ensAddrFuncHash, node)
+	if err != nil {
+		return "", err
+	}
+	if address == (common.Address{}) {
+		return "", errors.New("ens name is not resolved")
+	}
+
+	ensCacheLock.Lock()
+	ensCache[key] = &ensCacheItem{
+		address:  address.String(),
+		expireAt: time.Now().Add(ensCacheLifetime),
+	}
+	ensCacheLock.Unlock()
+	return address.String(), nil
+}
+
+func (b *Bridge) callEnsAddress(contract string, funcHash []byte, node common.Hash) (common.Address, error) {
+	data := PackDataWithFuncHash(funcHash, node)
+	result, err := b.CallContract(contract, data, b.GatewayConfig.GetBlockTag("latest"))
+	if err != nil {
+		return common.Address{}, err
+	}
+	return common.BytesToAddress(common.GetData(common.FromHex(result), 0, 32)), nil
+}
@@ -0,0 +1,48 @@
+package eth
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/types"
+)
+
+// FeesSpent get cumulative gas fee spent by swap txs of pair on this chain
+func (b *Bridge) FeesSpent(pairID string) *big.Int {
+	return tokens.GetFeesSpent(pairID, b.IsSrc)
+}
+
+// CalcTxFee calc tx fee by `gasUsed * effectiveGasPrice` of receipt
+func (b *Bridge) CalcTxFee(txHash string, receipt interface{}) (*big.Int, error) {
+	txr, ok := receipt.(*types.RPCTxReceipt)
+	if !ok || txr == nil {
+		var err error
+		txr, err = b.GetTransactionReceipt(txHash)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if txr.GasUsed == nil {
+		return nil, errors.New("receipt without gas used")
+	}
+	var gasPrice *big.Int
+	if txr.EffectiveGasPrice != nil {
+		gasPrice = txr.EffectiveGasPrice.ToInt()
+	} else {
+		tx, err := b.GetTransactionByHash(txHash)
+		if err != nil {
+			return nil, err
+		}
+		if tx.Price == nil {
+			return nil, errors.New("tx without gas price")
+		}
+		gasPrice = tx.Price.ToInt()
+	}
+	return new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(uint64(*txr.GasUsed))), nil
+}
+
+// ActualFee get the actual fee paid by tx (`gasUsed * effectiveGasPrice` of its receipt)
+func (b *Bridge) ActualFee(txHash string) (*big.Int, error) {
+	return b.CalcTxFee(txHash, nil)
+}
@@ -0,0 +1,99 @@
+package eth
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/common/hexutil"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/rpc/client"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+// confirmationFinality final if enough confirmations (PoW)
+type confirmationFinality struct {
+	b *Bridge
+}
+
+// IsBlockFinal check confirmations by elapsed time if configed,
+// otherwise check by block count
+func (f *confirmationFinality) IsBlockFinal(txStatus *tokens.TxStatus) (bool, error) {
+	chainCfg := f.b.GetChainConfig()
+	if chainCfg.ConfirmationSeconds > 0 {
+		if txStatus.BlockTime == 0 {
+			return false, nil
+		}
+		return uint64(time.Now().Unix()) >= txStatus.BlockTime+chainCfg.ConfirmationSeconds, nil
+	}
+	return txStatus.Confirmations >= *chainCfg.Confirmations, nil
+}
+
+// finalizedTagFinality final if not above the 'finalized' block (PoS)
+type finalizedTagFinality struct {
+	b *Bridge
+}
+
+// IsBlockFinal impl
+func (f *finalizedTagFinality) IsBlockFinal(txStatus *tokens.TxStatus) (bool, error) {
+	finalized, err := f.b.GetFinalizedBlockNumber()
+	if err != nil {
+		return false, err
+	}
+	return txStatus.BlockHeight <= finalized, nil
+}
+
+// checkpointFinality final if not above the latest checkpointed block
+type checkpointFinality struct {
+	b      *Bridge
+	method string
+}
+
+// IsBlockFinal impl
+func (f *checkpointFinality) IsBlockFinal(txStatus *tokens.TxStatus) (bool, error) {
+	checkpoint, err := f.b.GetCheckpointBlockNumber(f.method)
+	if err != nil {
+		return false, err
+	}
+	return txStatus.BlockHeight <= checkpoint, nil
+}
+
+// GetCheckpointBlockNumber call the checkpoint rpc method of chain
+func (b *Bridge) GetCheckpointBlockNumber(method string) (uint64, error) {
+	gateway := b.GatewayConfig
+	var result hexutil.Uint64
+	var err error
+	for _, apiAddress := range gateway.APIAddress {
+		url := apiAddress
+		err = client.RPCPost(&result, url, method)
+		if err == nil {
+			return uint64(result), nil
+		}
+	}
+	return 0, fmt.Errorf("call checkpoint method '%v' failed: %v", method, err)
+}
+
+// GetFinalityProvider get finality provider by 'FinalityMode' of chain config
+func (b *Bridge) GetFinalityProvider() tokens.FinalityProvider {
+	chainCfg := b.GetChainConfig()
+	switch chainCfg.FinalityMode {
+	case tokens.FinalityModeFinalized:
+		return &finalizedTagFinality{b: b}
+	case tokens.FinalityModeCheckpoint:
+		return &checkpointFinality{b: b, method: chainCfg.FinalityCheckpointMethod}
+	default:
+		return &confirmationFinality{b: b}
+	}
+}
+
+// IsTransactionFinal dispatch to the configed finality provider
+func (b *Bridge) IsTransactionFinal(txStatus *tokens.TxStatus) bool {
+	if txStatus.BlockHeight == 0 {
+		return false
+	}
+	final, err := b.GetFinalityProvider().IsBlockFinal(txStatus)
+	if err != nil {
+		log.Warn("check tx finality failed", "mode", b.GetChainConfig().FinalityMode, "height", txStatus.BlockHeight, "err", err)
+		return false
+	}
+	return final
+}
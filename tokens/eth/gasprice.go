@@ -0,0 +1,134 @@
+package eth
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/common/hexutil"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/rpc/client"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+// gasPriceCache cache the suggested gas price for a while
+type gasPriceCache struct {
+	lock       sync.Mutex
+	price      *big.Int
+	updateTime time.Time
+}
+
+// get return a copy of the cached price, or nil if it is older than ttl
+func (c *gasPriceCache) get(ttl time.Duration) *big.Int {
+	if ttl == 0 {
+		return nil
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.price == nil || time.Since(c.updateTime) > ttl {
+		return nil
+	}
+	return new(big.Int).Set(c.price)
+}
+
+func (c *gasPriceCache) set(price *big.Int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.price = new(big.Int).Set(price)
+	c.updateTime = time.Now()
+}
+
+func (b *Bridge) getGasPriceCacheTTL() time.Duration {
+	return time.Duration(b.ChainConfig.GasPriceCacheTTL) * time.Second
+}
+
+// getSwapGasPrice get gas price by the 'GasPriceStrategy' of pair
+func (b *Bridge) getSwapGasPrice(args *tokens.BuildTxArgs) (gasPrice *big.Int, err error) {
+	if args.SwapType == tokens.NoSwapType {
+		return b.getGasPrice()
+	}
+	tokenCfg := b.GetTokenConfig(args.PairID)
+	if tokenCfg == nil {
+		return nil, tokens.ErrUnknownPairID
+	}
+	switch tokenCfg.GasPriceStrategy {
+	case tokens.GasPriceStrategyFixed:
+		return tokenCfg.GetFixedGasPrice(), nil
+	case tokens.GasPriceStrategyOracle:
+		gasPrice, err = b.getOracleGasPrice()
+	default:
+		gasPrice, err = b.getGasPrice()
+	}
+	if err != nil {
+		fallback := tokenCfg.GetFallbackGasPrice()
+		if fallback == nil {
+			return nil, err
+		}
+		log.Warn("get gas price failed, use fallback gas price", "pairID", args.PairID, "fallback", fallback, "err", err)
+		return fallback, nil
+	}
+	addPercent := tokenCfg.GetPlusGasPricePercentage(args.SwapType) + b.getBaseFeeTrendPercentage()
+	if addPercent > 0 {
+		gasPrice.Mul(gasPrice, big.NewInt(int64(100+addPercent)))
+		gasPrice.Div(gasPrice, big.NewInt(100))
+	}
+	return gasPrice, nil
+}
+
+// applyGasPriceBounds bound gas price (or max fee per gas) by 'MinGasPriceGwei' and 'MaxGasPriceGwei' of pair
+func (b *Bridge) applyGasPriceBounds(args *tokens.BuildTxArgs, extra *tokens.EthExtraArgs) {
+	if args.SwapType == tokens.NoSwapType {
+		return
+	}
+	tokenCfg := b.GetTokenConfig(args.PairID)
+	if tokenCfg == nil {
+		return
+	}
+	minPrice, maxPrice := tokenCfg.GetMinGasPrice(), tokenCfg.GetMaxGasPrice()
+	bound := func(name string, price *big.Int) *big.Int {
+		switch {
+		case price == nil:
+		case minPrice != nil && price.Cmp(minPrice) < 0:
+			log.Info("raise "+name+" to configed floor", "pairID", args.PairID, "old", price, "new", minPrice)
+			return new(big.Int).Set(minPrice)
+		case maxPrice != nil && price.Cmp(maxPrice) > 0:
+			log.Warn("lower "+name+" to configed cap", "pairID", args.PairID, "old", price, "new", maxPrice)
+			return new(big.Int).Set(maxPrice)
+		}
+		return price
+	}
+	extra.GasPrice = bound("gas price", extra.GasPrice)
+	extra.GasFeeCap = bound("max fee per gas", extra.GasFeeCap)
+	if maxPrice != nil && extra.GasTipCap != nil && extra.GasTipCap.Cmp(maxPrice) > 0 {
+		extra.GasTipCap = new(big.Int).Set(maxPrice)
+	}
+}
+
+// getOracleGasPrice get median suggested gas price of all gateways
+// (one gateway quoting an abnormal price can not skew the result)
+func (b *Bridge) getOracleGasPrice() (*big.Int, error) {
+	if b.testStateProvider != nil {
+		return b.testStateProvider.GetGasPrice()
+	}
+	var prices []*big.Int
+	var err error
+	for _, apiAddress := range b.GatewayConfig.APIAddress {
+		var result hexutil.Big
+		err = client.RPCPost(&result, apiAddress, "eth_gasPrice")
+		if err == nil {
+			prices = append(prices, result.ToInt())
+		}
+	}
+	if len(prices) == 0 {
+		if err == nil {
+			err = errors.New("no gas price quoted")
+		}
+		return nil, err
+	}
+	sort.Slice(prices, func(i, j int) bool {
+		return prices[i].Cmp(prices[j]) < 0
+	})
+	return prices[len(prices)/2], nil
+}
@@ -0,0 +1,128 @@
+package eth
+
+import (
+	"encoding/asn1"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/rpc/client"
+	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
+	"github.com/anyswap/CrossChain-Bridge/types"
+)
+
+const kmsSignTimeout = 30 // seconds
+
+// DigestSigner sign tx digest with keys held outside (eg. HSM / KMS)
+type DigestSigner interface {
+	// SignDigest return 65 bytes signature in [R || S || V] form
+	SignDigest(digest []byte) ([]byte, error)
+}
+
+// KmsSigner sign digest with secp256k1 key in remote KMS
+type KmsSigner struct {
+	URL     string
+	KeyID   string
+	Address common.Address
+}
+
+type kmsSignRequest struct {
+	KeyID  string `json:"keyId"`
+	Digest string `json:"digest"`
+}
+
+type kmsSignResponse struct {
+	Signature string `json:"signature"` // hex of DER encoded ECDSA signature
+}
+
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+// NewKmsSigner new kms signer
+func NewKmsSigner(url, keyID, address string) *KmsSigner {
+	return &KmsSigner{
+		URL:     url,
+		KeyID:   keyID,
+		Address: common.HexToAddress(address),
+	}
+}
+
+// SignDigest impl DigestSigner
+func (s *KmsSigner) SignDigest(digest []byte) ([]byte, error) {
+	req := &kmsSignRequest{
+		KeyID:  s.KeyID,
+		Digest: common.ToHex(digest),
+	}
+	resp, err := client.HTTPPost(s.URL, req, nil, nil, kmsSignTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	const maxReadContentLength int64 = 1024 * 1024
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxReadContentLength))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("kms sign failed, status %v, message: %v", resp.StatusCode, string(body))
+	}
+	var result kmsSignResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, err
+	}
+	return recoverableSignature(digest, common.FromHex(result.Signature), s.Address)
+}
+
+// convert DER encoded signature to [R || S || V] form
+func recoverableSignature(digest, derSig []byte, address common.Address) ([]byte, error) {
+	var sig ecdsaSignature
+	if _, err := asn1.Unmarshal(derSig, &sig); err != nil {
+		return nil, fmt.Errorf("wrong der signature: %v", err)
+	}
+	if sig.R == nil || sig.S == nil {
+		return nil, errors.New("wrong der signature")
+	}
+	// normalize to lower s value (EIP-2)
+	curveN := crypto.S256().Params().N
+	if sig.S.Cmp(new(big.Int).Rsh(curveN, 1)) > 0 {
+		sig.S = new(big.Int).Sub(curveN, sig.S)
+	}
+	signature := make([]byte, crypto.SignatureLength)
+	copy(signature[:32], common.LeftPadBytes(sig.R.Bytes(), 32))
+	copy(signature[32:64], common.LeftPadBytes(sig.S.Bytes(), 32))
+	for v := byte(0); v < 2; v++ {
+		signature[64] = v
+		pubkey, err := crypto.SigToPub(digest, signature)
+		if err == nil && crypto.PubkeyToAddress(*pubkey) == address {
+			return signature, nil
+		}
+	}
+	return nil, errors.New("can not recover signer address from kms signature")
+}
+
+// SignTransactionWithDigestSigner sign tx with digest signer
+func (b *Bridge) SignTransactionWithDigestSigner(rawTx interface{}, signer DigestSigner) (signTx interface{}, txHash string, err error) {
+	tx, ok := rawTx.(*types.Transaction)
+	if !ok {
+		return nil, "", errors.New("wrong raw tx param")
+	}
+	msgHash := b.Signer.Hash(tx)
+	signature, err := signer.SignDigest(msgHash.Bytes())
+	if err != nil {
+		return nil, "", fmt.Errorf("sign digest failed, %v", err)
+	}
+	signedTx, err := tx.WithSignature(b.Signer, signature)
+	if err != nil {
+		return nil, "", err
+	}
+	txHash = signedTx.Hash().String()
+	log.Info(b.ChainConfig.BlockChain+" SignTransactionWithDigestSigner success", "txhash", txHash, "nonce", signedTx.Nonce())
+	return signedTx, txHash, nil
+}
@@ -0,0 +1,85 @@
+package eth
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/common/hexutil"
+	"github.com/anyswap/CrossChain-Bridge/types"
+)
+
+// L1FeeEstimator estimate the L1 data fee of L2 tx with the given RLP-encoded unsigned tx
+type L1FeeEstimator func(b *Bridge, txData []byte) (*big.Int, error)
+
+// L1 fee estimator names
+const (
+	L1FeeEstimatorOptimism = "Optimism"
+)
+
+var (
+	// GasPriceOracle predeploy of OP stack chains
+	optimismGasPriceOracle = "0x420000000000000000000000000000000000000F"
+
+	// first 4 bytes of `Keccak256Hash([]byte("getL1Fee(bytes)"))`
+	getL1FeeFuncHash = common.FromHex("0x49948e0e")
+
+	l1FeeEstimators = map[string]L1FeeEstimator{
+		L1FeeEstimatorOptimism: estimateOptimismL1Fee,
+	}
+	l1FeeEstimatorsLock sync.RWMutex
+)
+
+// RegisterL1FeeEstimator register L1 fee estimator (should be called before bridge init)
+func RegisterL1FeeEstimator(name string, estimator L1FeeEstimator) {
+	l1FeeEstimatorsLock.Lock()
+	defer l1FeeEstimatorsLock.Unlock()
+	l1FeeEstimators[name] = estimator
+}
+
+func getL1FeeEstimator(name string) (estimator L1FeeEstimator, exist bool) {
+	l1FeeEstimatorsLock.RLock()
+	defer l1FeeEstimatorsLock.RUnlock()
+	estimator, exist = l1FeeEstimators[name]
+	return estimator, exist
+}
+
+func (b *Bridge) verifyL1FeeEstimator() error {
+	name := b.ChainConfig.L1FeeEstimator
+	if name == "" {
+		return nil
+	}
+	if _, exist := getL1FeeEstimator(name); !exist {
+		return fmt.Errorf("unknown 'L1FeeEstimator' '%v'", name)
+	}
+	return nil
+}
+
+// estimateL1Fee estimate L1 data fee by 'L1FeeEstimator' of chain (zero if not configed)
+func (b *Bridge) estimateL1Fee(tx *types.Transaction) (*big.Int, error) {
+	name := b.ChainConfig.L1FeeEstimator
+	if name == "" || b.testStateProvider != nil {
+		return big.NewInt(0), nil
+	}
+	estimator, exist := getL1FeeEstimator(name)
+	if !exist {
+		return nil, fmt.Errorf("unknown 'L1FeeEstimator' '%v'", name)
+	}
+	txData, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return estimator(b, txData)
+}
+
+// estimateOptimismL1Fee call `getL1Fee(bytes)` of GasPriceOracle
+// with the RLP-encoded unsigned tx (the oracle adds fixed signature overhead)
+func estimateOptimismL1Fee(b *Bridge, txData []byte) (*big.Int, error) {
+	data := hexutil.Bytes(PackDataWithFuncHash(getL1FeeFuncHash, txData))
+	result, err := b.CallContract(optimismGasPriceOracle, data, "latest")
+	if err != nil {
+		return nil, err
+	}
+	return common.GetBigIntFromStr(result)
+}
@@ -0,0 +1,44 @@
+package eth
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/types"
+)
+
+func TestEstimateL1FeeWithEncodedTx(t *testing.T) {
+	var got []byte
+	RegisterL1FeeEstimator("testL1Fee", func(b *Bridge, txData []byte) (*big.Int, error) {
+		got = txData
+		return big.NewInt(int64(len(txData))), nil
+	})
+	defer func() {
+		l1FeeEstimatorsLock.Lock()
+		delete(l1FeeEstimators, "testL1Fee")
+		l1FeeEstimatorsLock.Unlock()
+	}()
+
+	b := NewCrossChainBridge(true)
+	b.ChainConfig = &tokens.ChainConfig{L1FeeEstimator: "testL1Fee"}
+	if err := b.verifyL1FeeEstimator(); err != nil {
+		t.Fatal(err)
+	}
+
+	input := common.FromHex("0xa9059cbb")
+	tx := types.NewTransaction(1, common.HexToAddress(testSwapContract), big.NewInt(1), 50000, big.NewInt(1e9), input)
+	l1Fee, err := b.estimateL1Fee(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := tx.MarshalBinary()
+	if !bytes.Equal(got, want) {
+		t.Errorf("estimate l1 fee: want encoded tx %x, got %x", want, got)
+	}
+	if l1Fee.Cmp(big.NewInt(int64(len(want)))) != 0 {
+		t.Errorf("estimate l1 fee: want %v, got %v", len(want), l1Fee)
+	}
+}
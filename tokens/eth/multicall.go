@@ -0,0 +1,135 @@
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/common/hexutil"
+)
+
+// first 4 bytes of `Keccak256Hash([]byte("aggregate3((address,bool,bytes)[])"))`
+var multicallAggregate3FuncHash = common.FromHex("0x82ad56cb")
+
+// MultiCall call item of Multicall3 contract
+type MultiCall struct {
+	Target       string
+	AllowFailure bool
+	CallData     []byte
+}
+
+// MultiCallResult call result of Multicall3 contract
+type MultiCallResult struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// AggregateCalls call many contracts in one rpc through Multicall3 contract
+func (b *Bridge) AggregateCalls(calls []MultiCall) ([]MultiCallResult, error) {
+	multicall := b.ChainConfig.MulticallAddress
+	if multicall == "" {
+		return nil, errors.New("multicall address is not configed")
+	}
+	if len(calls) == 0 {
+		return nil, nil
+	}
+	data := make(hexutil.Bytes, 0, 4+len(calls)*256)
+	data = append(data, multicallAggregate3FuncHash...)
+	data = append(data, packMultiCalls(calls)...)
+	result, err := b.CallContract(multicall, data, b.GatewayConfig.GetBlockTag("latest"))
+	if err != nil {
+		return nil, err
+	}
+	results, err := unpackMultiCallResults(common.FromHex(result))
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != len(calls) {
+		return nil, fmt.Errorf("multicall result count mismatch, want %v but have %v", len(calls), len(results))
+	}
+	return results, nil
+}
+
+// GetErc20Balances get erc20 balances of address through Multicall3 contract
+func (b *Bridge) GetErc20Balances(contracts []string, address string) ([]*big.Int, error) {
+	calls := make([]MultiCall, len(contracts))
+	for i, contract := range contracts {
+		calls[i] = MultiCall{
+			Target:   contract,
+			CallData: PackDataWithFuncHash(erc20CodeParts["balanceOf"], common.HexToAddress(address)),
+		}
+	}
+	results, err := b.AggregateCalls(calls)
+	if err != nil {
+		return nil, err
+	}
+	balances := make([]*big.Int, len(results))
+	for i, res := range results {
+		if !res.Success || len(res.ReturnData) < 32 {
+			return nil, fmt.Errorf("multicall balanceOf of %v failed", contracts[i])
+		}
+		balances[i] = common.GetBigInt(res.ReturnData, 0, 32)
+	}
+	return balances, nil
+}
+
+// pack `(address,bool,bytes)[]` as the only argument
+func packMultiCalls(calls []MultiCall) []byte {
+	var tuples []byte
+	offsets := make([]byte, 0, len(calls)*32)
+	base := len(calls) * 32
+	for _, call := range calls {
+		offsets = append(offsets, packBigInt(big.NewInt(int64(base+len(tuples))))...)
+		tuples = append(tuples, packAddress(common.HexToAddress(call.Target))...)
+		tuples = append(tuples, packBool(call.AllowFailure)...)
+		tuples = append(tuples, packBigInt(big.NewInt(96))...)
+		tuples = append(tuples, packBytes(call.CallData)...)
+	}
+	bs := make([]byte, 0, 64+len(offsets)+len(tuples))
+	bs = append(bs, packBigInt(big.NewInt(32))...)
+	bs = append(bs, packBigInt(big.NewInt(int64(len(calls))))...)
+	bs = append(bs, offsets...)
+	bs = append(bs, tuples...)
+	return bs
+}
+
+// isInRange check [start, start+length) is in data of dataLength without overflow
+func isInRange(dataLength, start, length uint64) bool {
+	return start <= dataLength && length <= dataLength-start
+}
+
+// unpack `(bool,bytes)[]` as the only return value
+func unpackMultiCallResults(data []byte) ([]MultiCallResult, error) {
+	errWrongData := errors.New("wrong multicall result data")
+	dataLength := uint64(len(data))
+	offset, overflow := common.GetUint64(data, 0, 32)
+	if overflow || !isInRange(dataLength, offset, 32) {
+		return nil, errWrongData
+	}
+	count, overflow := common.GetUint64(data, offset, 32)
+	base := offset + 32
+	if overflow || count > (dataLength-base)/32 {
+		return nil, errWrongData
+	}
+	results := make([]MultiCallResult, count)
+	for i := uint64(0); i < count; i++ {
+		tupleOffset, overflow := common.GetUint64(data, base+i*32, 32)
+		if overflow || !isInRange(dataLength, base, tupleOffset) || !isInRange(dataLength, base+tupleOffset, 64) {
+			return nil, errWrongData
+		}
+		tupleStart := base + tupleOffset
+		results[i].Success = common.GetBigInt(data, tupleStart, 32).Sign() != 0
+		bytesOffset, overflow := common.GetUint64(data, tupleStart+32, 32)
+		if overflow || !isInRange(dataLength, tupleStart, bytesOffset) || !isInRange(dataLength, tupleStart+bytesOffset, 32) {
+			return nil, errWrongData
+		}
+		bytesStart := tupleStart + bytesOffset
+		length, overflow := common.GetUint64(data, bytesStart, 32)
+		if overflow || !isInRange(dataLength, bytesStart+32, length) {
+			return nil, errWrongData
+		}
+		results[i].ReturnData = common.GetData(data, bytesStart+32, length)
+	}
+	return results, nil
+}
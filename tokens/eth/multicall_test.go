@@ -0,0 +1,99 @@
+package eth
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+// pack `(bool,bytes)[]` as the only return value
+func packMultiCallResults(results []MultiCallResult) []byte {
+	var tuples []byte
+	offsets := make([]byte, 0, len(results)*32)
+	base := len(results) * 32
+	for _, res := range results {
+		offsets = append(offsets, packBigInt(big.NewInt(int64(base+len(tuples))))...)
+		tuples = append(tuples, packBool(res.Success)...)
+		tuples = append(tuples, packBigInt(big.NewInt(64))...)
+		tuples = append(tuples, packBytes(res.ReturnData)...)
+	}
+	bs := packBigInt(big.NewInt(32))
+	bs = append(bs, packBigInt(big.NewInt(int64(len(results))))...)
+	bs = append(bs, offsets...)
+	return append(bs, tuples...)
+}
+
+func TestUnpackMultiCallResults(t *testing.T) {
+	want := []MultiCallResult{
+		{Success: true, ReturnData: packBigInt(big.NewInt(100))},
+		{Success: false, ReturnData: []byte{}},
+	}
+	results, err := unpackMultiCallResults(packMultiCallResults(want))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != len(want) {
+		t.Fatalf("want %v results, got %v", len(want), len(results))
+	}
+	for i, res := range results {
+		if res.Success != want[i].Success || !bytes.Equal(res.ReturnData, want[i].ReturnData) {
+			t.Errorf("result %v: want %+v, got %+v", i, want[i], res)
+		}
+	}
+}
+
+func TestUnpackHostileMultiCallResults(t *testing.T) {
+	maxUint64 := new(big.Int).SetUint64(^uint64(0))
+	cases := map[string][]byte{
+		"huge offset":    packBigInt(new(big.Int).Sub(maxUint64, big.NewInt(16))),
+		"huge count":     append(packBigInt(big.NewInt(32)), packBigInt(new(big.Int).Rsh(maxUint64, 4))...),
+		"overflow count": append(packBigInt(big.NewInt(32)), packBigInt(maxUint64)...),
+		"huge tuple offset": append(append(packBigInt(big.NewInt(32)), packBigInt(big.NewInt(1))...),
+			packBigInt(new(big.Int).Sub(maxUint64, big.NewInt(32)))...),
+	}
+	for name, data := range cases {
+		if _, err := unpackMultiCallResults(data); err == nil {
+			t.Errorf("unpack %v should fail", name)
+		}
+	}
+}
+
+func TestGetErc20BalancesFailedCall(t *testing.T) {
+	results := []MultiCallResult{
+		{Success: true, ReturnData: packBigInt(big.NewInt(100))},
+		{Success: false, ReturnData: packBigInt(big.NewInt(200))},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID int `json:"id"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":"%v"}`, req.ID, common.ToHex(packMultiCallResults(results)))
+	}))
+	defer server.Close()
+
+	b := NewCrossChainBridge(true)
+	b.ChainConfig = &tokens.ChainConfig{MulticallAddress: "0xcA11bde05977b3631167028862bE2a173976CA11"}
+	b.GatewayConfig = &tokens.GatewayConfig{APIAddress: []string{server.URL}}
+
+	contracts := []string{testSwapContract, "0x5555555555555555555555555555555555555555"}
+	if _, err := b.GetErc20Balances(contracts, testSwapDcrm); err == nil {
+		t.Fatal("get erc20 balances with failed call should fail")
+	}
+
+	results[1].Success = true
+	balances, err := b.GetErc20Balances(contracts, testSwapDcrm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balances[0].Cmp(big.NewInt(100)) != 0 || balances[1].Cmp(big.NewInt(200)) != 0 {
+		t.Errorf("wrong erc20 balances %v", balances)
+	}
+}
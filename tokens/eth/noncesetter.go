@@ -2,6 +2,9 @@ package eth
 
 import (
 	"strings"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/rpc/client"
 )
 
 // NonceSetterBase base nonce setter
@@ -29,34 +32,79 @@ func (b *Bridge) SetNonce(pairID string, value uint64) {
 	}
 }
 
+// alignNonce get the least nonce not less than 'nonce' with 'nonce % stride == offset'.
+// Invariant: every nonce we use is congruent to offset modulo stride,
+// so processes with the same stride and distinct offsets never use the same nonce.
+func alignNonce(nonce, stride, offset uint64) uint64 {
+	if stride <= 1 {
+		return nonce
+	}
+	return nonce + (offset+stride-nonce%stride)%stride
+}
+
+func (b *Bridge) nonceStride() uint64 {
+	if b.ChainConfig == nil || b.ChainConfig.NonceStride <= 1 {
+		return 1
+	}
+	return b.ChainConfig.NonceStride
+}
+
+func (b *Bridge) alignNonce(nonce uint64) uint64 {
+	if b.ChainConfig == nil {
+		return nonce
+	}
+	return alignNonce(nonce, b.ChainConfig.NonceStride, b.ChainConfig.NonceOffset)
+}
+
 // AdjustNonce adjust account nonce (eth like chain)
+// the returned and stored nonce is aligned to configed 'NonceStride' and 'NonceOffset'
 func (b *Bridge) AdjustNonce(pairID string, value uint64) (nonce uint64) {
 	tokenCfg := b.GetTokenConfig(pairID)
 	account := strings.ToLower(tokenCfg.DcrmAddress)
-	nonce = value
+	nonceMap := b.SwapinNonce
 	if b.IsSrcEndpoint() {
-		if b.SwapoutNonce[account] > value {
-			nonce = b.SwapoutNonce[account]
-		} else {
-			b.SwapoutNonce[account] = value
-		}
-	} else {
-		if b.SwapinNonce[account] > value {
-			nonce = b.SwapinNonce[account]
-		} else {
-			b.SwapinNonce[account] = value
-		}
+		nonceMap = b.SwapoutNonce
+	}
+	nonce = value
+	if nonceMap[account] > value {
+		nonce = nonceMap[account]
 	}
+	nonce = b.alignNonce(nonce)
+	nonceMap[account] = nonce
 	return nonce
 }
 
-// IncreaseNonce decrease account nonce (eth like chain)
+// IncreaseNonce increase account nonce by 'value' aligned slots (eth like chain)
 func (b *Bridge) IncreaseNonce(pairID string, value uint64) {
 	tokenCfg := b.GetTokenConfig(pairID)
 	account := strings.ToLower(tokenCfg.DcrmAddress)
 	if b.IsSrcEndpoint() {
-		b.SwapoutNonce[account] += value
+		b.SwapoutNonce[account] += value * b.nonceStride()
+	} else {
+		b.SwapinNonce[account] += value * b.nonceStride()
+	}
+}
+
+// ResyncNonce reset account nonce to its pending pool nonce (eth like chain)
+func (b *Bridge) ResyncNonce(address string) (nonce uint64, err error) {
+	for i := 0; i < retryRPCCount; i++ {
+		nonce, err = b.GetNonce(address, b.GatewayConfig.GetNonceBlockTag())
+		if err == nil {
+			break
+		}
+		if !client.IsTransientError(err) {
+			break
+		}
+		time.Sleep(retryRPCInterval)
+	}
+	if err != nil {
+		return 0, err
+	}
+	account := strings.ToLower(address)
+	if b.IsSrcEndpoint() {
+		b.SwapoutNonce[account] = nonce
 	} else {
-		b.SwapinNonce[account] += value
+		b.SwapinNonce[account] = nonce
 	}
+	return nonce, nil
 }
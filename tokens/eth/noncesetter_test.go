@@ -0,0 +1,74 @@
+package eth
+
+import (
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+func TestAlignNonce(t *testing.T) {
+	cases := []struct {
+		nonce, stride, offset, want uint64
+	}{
+		{7, 0, 0, 7},
+		{7, 1, 0, 7},
+		{6, 2, 0, 6},
+		{7, 2, 0, 8},
+		{6, 2, 1, 7},
+		{7, 2, 1, 7},
+		{10, 3, 2, 11},
+		{11, 3, 2, 11},
+		{12, 3, 2, 14},
+	}
+	for _, c := range cases {
+		got := alignNonce(c.nonce, c.stride, c.offset)
+		if got != c.want {
+			t.Errorf("alignNonce(%v, %v, %v): want %v, got %v", c.nonce, c.stride, c.offset, c.want, got)
+		}
+		if c.stride > 1 && got%c.stride != c.offset {
+			t.Errorf("alignNonce(%v, %v, %v) = %v breaks invariant", c.nonce, c.stride, c.offset, got)
+		}
+	}
+}
+
+func TestAdjustNonceWithStride(t *testing.T) {
+	pairID := "testpair"
+	tokens.SetTokenPairsConfig(map[string]*tokens.TokenPairConfig{
+		pairID: {
+			PairID:    pairID,
+			SrcToken:  &tokens.TokenConfig{DcrmAddress: "0x0000000000000000000000000000000000000001"},
+			DestToken: &tokens.TokenConfig{DcrmAddress: "0x0000000000000000000000000000000000000001"},
+		},
+	}, false)
+	defer tokens.SetTokenPairsConfig(nil, false)
+
+	// two processes sharing one address with even/odd split
+	even := NewCrossChainBridge(false)
+	even.ChainConfig = &tokens.ChainConfig{NonceStride: 2, NonceOffset: 0}
+	odd := NewCrossChainBridge(false)
+	odd.ChainConfig = &tokens.ChainConfig{NonceStride: 2, NonceOffset: 1}
+
+	used := make(map[uint64]bool)
+	poolNonce := uint64(5)
+	for i := 0; i < 5; i++ {
+		for _, b := range []*Bridge{even, odd} {
+			nonce := b.AdjustNonce(pairID, poolNonce)
+			if nonce%2 != b.ChainConfig.NonceOffset {
+				t.Fatalf("nonce %v is not congruent to offset %v", nonce, b.ChainConfig.NonceOffset)
+			}
+			if used[nonce] {
+				t.Fatalf("nonce %v is used twice", nonce)
+			}
+			used[nonce] = true
+			// follow the worker sequence: increase after sending
+			b.IncreaseNonce(pairID, 1)
+		}
+	}
+
+	// default stride 1 and offset 0 keep the original behavior
+	plain := NewCrossChainBridge(false)
+	plain.ChainConfig = &tokens.ChainConfig{}
+	if nonce := plain.AdjustNonce(pairID, 5); nonce != 5 {
+		t.Errorf("default stride should not change nonce, got %v", nonce)
+	}
+}
@@ -0,0 +1,55 @@
+package eth
+
+import (
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+var (
+	// `keccak256("eip1967.proxy.implementation") - 1`
+	eip1967ImplementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+	// `keccak256("eip1967.proxy.beacon") - 1`
+	eip1967BeaconSlot = common.HexToHash("0xa3f0ad74e5423aebfd80d3ef4346578335a9a72aeaee59ff6cb3582b35133d50")
+)
+
+// GetCodeSize get code size of address (0 for externally owned account)
+func (b *Bridge) GetCodeSize(address string) (int, error) {
+	code, err := b.GetCode(address)
+	if err != nil {
+		return 0, err
+	}
+	return len(code), nil
+}
+
+// GetProxyImplementation get implementation (or beacon) address stored in
+// EIP-1967 proxy slots, and return false if contract is not a known proxy
+func (b *Bridge) GetProxyImplementation(contract string) (impl common.Address, isProxy bool, err error) {
+	for _, slot := range []common.Hash{eip1967ImplementationSlot, eip1967BeaconSlot} {
+		var value common.Hash
+		value, err = b.GetStorageAt(contract, slot, "")
+		if err != nil {
+			return common.Address{}, false, err
+		}
+		if value != (common.Hash{}) {
+			return common.BytesToAddress(value.Bytes()), true, nil
+		}
+	}
+	return common.Address{}, false, nil
+}
+
+// only log warning if erc20 contract is upgradeable proxy, as it's a risk instead of an error
+func (b *Bridge) checkUpgradeableContract(tokenCfg *tokens.TokenConfig) {
+	if !tokenCfg.IsErc20() {
+		return
+	}
+	contract := tokenCfg.ContractAddress
+	impl, isProxy, err := b.GetProxyImplementation(contract)
+	if err != nil {
+		log.Warn("check proxy contract failed", "contract", contract, "err", err)
+		return
+	}
+	if isProxy {
+		log.Warn("token contract is upgradeable proxy", "symbol", tokenCfg.Symbol, "contract", contract, "implementation", impl.String())
+	}
+}
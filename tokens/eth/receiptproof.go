@@ -0,0 +1,248 @@
+package eth
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/tools/rlp"
+	"github.com/anyswap/CrossChain-Bridge/types"
+)
+
+var (
+	errProofNodeMissing = errors.New("receipt proof missing node")
+	errProofKeyNotFound = errors.New("receipt proof key not found")
+	errProofWrongNode   = errors.New("receipt proof with wrong node")
+)
+
+type receiptLogRLP struct {
+	Address common.Address
+	Topics  []common.Hash
+	Data    []byte
+}
+
+type receiptRLP struct {
+	PostStateOrStatus []byte
+	CumulativeGasUsed uint64
+	Bloom             []byte
+	Logs              []*receiptLogRLP
+}
+
+// VerifyReceiptProof verify receipt of tx by merkle proof (rlp encoded trie nodes
+// from root to leaf) against the 'receiptsRoot' of block, so that the receipt
+// reported by rpc is proved to be the one committed in the block
+func (b *Bridge) VerifyReceiptProof(txHash, blockHash string, proof [][]byte) (*types.RPCTxReceipt, error) {
+	receipt, err := b.GetTransactionReceipt(txHash)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.BlockHash == nil || !common.IsEqualIgnoreCase(receipt.BlockHash.Hex(), blockHash) {
+		return nil, fmt.Errorf("receipt block hash mismatch, have %v want %v", receipt.BlockHash, blockHash)
+	}
+	if receipt.TxIndex == nil {
+		return nil, errors.New("receipt without tx index")
+	}
+	block, err := b.GetBlockByHash(blockHash)
+	if err != nil {
+		return nil, err
+	}
+	if block.ReceiptHash == nil {
+		return nil, errors.New("block without receipts root")
+	}
+	key, err := rlp.EncodeToBytes(uint64(*receipt.TxIndex))
+	if err != nil {
+		return nil, err
+	}
+	value, err := VerifyMerkleProof(*block.ReceiptHash, key, proof)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := EncodeReceipt(receipt)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(value, encoded) {
+		return nil, errors.New("receipt mismatch with proof")
+	}
+	return receipt, nil
+}
+
+// EncodeReceipt encode receipt in consensus format (as in receipt trie)
+func EncodeReceipt(receipt *types.RPCTxReceipt) ([]byte, error) {
+	var enc receiptRLP
+	switch {
+	case receipt.PostState != nil && len(*receipt.PostState) > 0:
+		enc.PostStateOrStatus = *receipt.PostState
+	case receipt.Status != nil && *receipt.Status == 1:
+		enc.PostStateOrStatus = []byte{1}
+	case receipt.Status != nil:
+		enc.PostStateOrStatus = []byte{}
+	default:
+		return nil, errors.New("receipt without status or post state")
+	}
+	if receipt.CumulativeGasUsed == nil || receipt.Bloom == nil {
+		return nil, errors.New("receipt without cumulative gas used or bloom")
+	}
+	enc.CumulativeGasUsed = uint64(*receipt.CumulativeGasUsed)
+	enc.Bloom = *receipt.Bloom
+	enc.Logs = make([]*receiptLogRLP, 0, len(receipt.Logs))
+	for _, log := range receipt.Logs {
+		if log.Address == nil {
+			return nil, errors.New("receipt log without address")
+		}
+		encLog := &receiptLogRLP{
+			Address: *log.Address,
+			Topics:  log.Topics,
+			Data:    []byte{},
+		}
+		if encLog.Topics == nil {
+			encLog.Topics = []common.Hash{}
+		}
+		if log.Data != nil {
+			encLog.Data = *log.Data
+		}
+		enc.Logs = append(enc.Logs, encLog)
+	}
+	data, err := rlp.EncodeToBytes(&enc)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Type != nil && *receipt.Type != types.LegacyTxType {
+		data = append([]byte{byte(*receipt.Type)}, data...)
+	}
+	return data, nil
+}
+
+// VerifyMerkleProof verify merkle patricia trie proof and return the value of key
+func VerifyMerkleProof(rootHash common.Hash, key []byte, proof [][]byte) (value []byte, err error) {
+	proofDB := make(map[common.Hash][]byte, len(proof))
+	for _, node := range proof {
+		proofDB[common.Keccak256Hash(node)] = node
+	}
+	nibbles := keyToNibbles(key)
+	node, exist := proofDB[rootHash]
+	if !exist {
+		return nil, errProofNodeMissing
+	}
+	for {
+		var elems [][]byte
+		elems, err = splitTrieNode(node)
+		if err != nil {
+			return nil, err
+		}
+		var child []byte
+		switch len(elems) {
+		case 17: // branch node
+			if len(nibbles) == 0 {
+				return decodeTrieValue(elems[16])
+			}
+			child = elems[nibbles[0]]
+			nibbles = nibbles[1:]
+		case 2: // extension or leaf node
+			var path []byte
+			var isLeaf bool
+			path, isLeaf, err = decodeCompactPath(elems[0])
+			if err != nil {
+				return nil, err
+			}
+			if isLeaf {
+				if !bytes.Equal(path, nibbles) {
+					return nil, errProofKeyNotFound
+				}
+				return decodeTrieValue(elems[1])
+			}
+			if len(nibbles) < len(path) || !bytes.Equal(path, nibbles[:len(path)]) {
+				return nil, errProofKeyNotFound
+			}
+			child = elems[1]
+			nibbles = nibbles[len(path):]
+		default:
+			return nil, errProofWrongNode
+		}
+		node, err = resolveTrieChild(child, proofDB)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// splitTrieNode split rlp list of trie node into raw elements
+func splitTrieNode(node []byte) ([][]byte, error) {
+	content, _, err := rlp.SplitList(node)
+	if err != nil {
+		return nil, errProofWrongNode
+	}
+	var elems [][]byte
+	for len(content) > 0 {
+		_, _, rest, errs := rlp.Split(content)
+		if errs != nil {
+			return nil, errProofWrongNode
+		}
+		elems = append(elems, content[:len(content)-len(rest)])
+		content = rest
+	}
+	return elems, nil
+}
+
+// resolveTrieChild resolve child reference which is either a hash or an embedded node
+func resolveTrieChild(child []byte, proofDB map[common.Hash][]byte) ([]byte, error) {
+	kind, content, _, err := rlp.Split(child)
+	if err != nil {
+		return nil, errProofWrongNode
+	}
+	switch {
+	case kind == rlp.List:
+		return child, nil
+	case len(content) == 0:
+		return nil, errProofKeyNotFound
+	case len(content) == common.HashLength:
+		node, exist := proofDB[common.BytesToHash(content)]
+		if !exist {
+			return nil, errProofNodeMissing
+		}
+		return node, nil
+	default:
+		return nil, errProofWrongNode
+	}
+}
+
+func decodeTrieValue(elem []byte) ([]byte, error) {
+	content, _, err := rlp.SplitString(elem)
+	if err != nil {
+		return nil, errProofWrongNode
+	}
+	if len(content) == 0 {
+		return nil, errProofKeyNotFound
+	}
+	return content, nil
+}
+
+// decodeCompactPath decode hex prefix encoded path into nibbles
+func decodeCompactPath(elem []byte) (path []byte, isLeaf bool, err error) {
+	compact, _, err := rlp.SplitString(elem)
+	if err != nil || len(compact) == 0 {
+		return nil, false, errProofWrongNode
+	}
+	flag := compact[0] >> 4
+	if flag > 3 {
+		return nil, false, errProofWrongNode
+	}
+	isLeaf = flag >= 2
+	nibbles := keyToNibbles(compact)
+	if flag&1 == 1 {
+		path = nibbles[1:] // odd length, skip flag nibble
+	} else {
+		path = nibbles[2:] // even length, skip flag and padding nibbles
+	}
+	return path, isLeaf, nil
+}
+
+func keyToNibbles(key []byte) []byte {
+	nibbles := make([]byte, len(key)*2)
+	for i, b := range key {
+		nibbles[i*2] = b >> 4
+		nibbles[i*2+1] = b & 0x0f
+	}
+	return nibbles
+}
@@ -0,0 +1,64 @@
+package eth
+
+import (
+	"errors"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+// IsTxReverted is tx mined with failed status
+func (b *Bridge) IsTxReverted(txHash string) (bool, error) {
+	receipt, err := b.GetTransactionReceipt(txHash)
+	if err != nil {
+		return false, err
+	}
+	if receipt.BlockNumber == nil {
+		return false, tokens.ErrTxNotStable
+	}
+	return receipt.Status != nil && *receipt.Status == 0, nil
+}
+
+// BuildRefundTx build tx to refund reverted swapout by minting the burned value
+// ('OriginValue') back to the sender of swapout tx ('SwapID') on this chain
+func (b *Bridge) BuildRefundTx(args *tokens.BuildTxArgs) (rawTx interface{}, err error) {
+	if b.IsSrc {
+		return nil, tokens.ErrBuildSwapTxInWrongEndpoint
+	}
+	if args.TxType != tokens.RefundSwapoutTx {
+		return nil, errors.New("refund tx require tx type " + tokens.RefundSwapoutTx.String())
+	}
+	if args.OriginValue == nil || args.OriginValue.Sign() <= 0 {
+		return nil, errors.New("refund tx require positive value")
+	}
+	tokenCfg := b.GetTokenConfig(args.PairID)
+	if tokenCfg == nil {
+		return nil, tokens.ErrUnknownPairID
+	}
+	swapoutTx, err := b.GetTransactionByHash(args.SwapID)
+	if err != nil {
+		return nil, err
+	}
+	if swapoutTx.From == nil {
+		return nil, errors.New("swapout tx without sender")
+	}
+	input := EncodeSwapinInput(common.HexToHash(args.SwapID), *swapoutTx.From, args.OriginValue)
+	args.Input = &input                      // input
+	args.To = tokenCfg.ContractAddress       // to
+	args.Identifier = params.GetIdentifier() // for accepting dcrm sign
+	if args.From == "" {
+		args.From = tokenCfg.DcrmAddress // from
+	}
+	if args.Extra == nil || args.Extra.EthExtra == nil {
+		args.Extra = &tokens.AllExtras{EthExtra: &tokens.EthExtraArgs{}}
+	}
+	if args.Extra.EthExtra.Nonce == nil {
+		// follow the local nonce of swapin txs
+		args.Extra.EthExtra.Nonce, err = b.getAccountNonce(args.PairID, args.From, tokens.SwapinType)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return b.BuildRawTransaction(args)
+}
@@ -20,6 +20,14 @@ var (
 	restIntervalInScanJob  = 3 * time.Second
 )
 
+// getScanRestInterval scan about once per block if 'AverageBlockTime' is configed
+func (b *Bridge) getScanRestInterval() time.Duration {
+	if b.ChainConfig.AverageBlockTime == 0 {
+		return restIntervalInScanJob
+	}
+	return b.ChainConfig.GetAverageBlockTime()
+}
+
 func (b *Bridge) getStartAndLatestHeight() (start, latest uint64) {
 	startHeight := tools.GetLatestScanHeight(b.IsSrc)
 
@@ -108,7 +116,7 @@ func (b *Bridge) StartChainTransactionScanJob() {
 		if quickSyncFinish {
 			_ = tools.UpdateLatestScanInfo(b.IsSrc, stable)
 		}
-		time.Sleep(restIntervalInScanJob)
+		time.Sleep(b.getScanRestInterval())
 	}
 }
 
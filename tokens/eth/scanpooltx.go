@@ -34,6 +34,6 @@ func (b *Bridge) StartPoolTransactionScanJob() {
 			b.processTransaction(txid)
 			scannedTxs.CacheScannedTx(txid)
 		}
-		time.Sleep(restIntervalInScanJob)
+		time.Sleep(b.getScanRestInterval())
 	}
 }
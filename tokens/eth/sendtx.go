@@ -3,8 +3,11 @@ package eth
 import (
 	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/anyswap/CrossChain-Bridge/common"
 	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
 	"github.com/anyswap/CrossChain-Bridge/types"
 )
 
@@ -15,13 +18,66 @@ func (b *Bridge) SendTransaction(signedTx interface{}) (txHash string, err error
 		fmt.Printf("signed tx is %+v\n", signedTx)
 		return "", errors.New("wrong signed transaction type")
 	}
+	if b.IsWrongChain() {
+		return "", tokens.ErrWrongChain
+	}
 	txHash = tx.Hash().String()
-	err = b.SendSignedTransaction(tx)
+	sentHash, err := b.SendSignedTransaction(tx)
 	if err != nil {
 		log.Info("SendTransaction failed", "hash", txHash, "err", err)
+		if isNonceError(err) {
+			b.resyncNonceOfSender(tx)
+			return txHash, tokens.ErrTxWithWrongNonce
+		}
 		return txHash, err
 	}
+	if sentHash != "" && !strings.EqualFold(sentHash, txHash) {
+		log.Warn("SendTransaction hash mismatch", "hash", txHash, "sentHash", sentHash)
+	}
 	log.Info("SendTransaction success", "hash", txHash)
 	//#log.Trace("SendTransaction success", "raw", tx.RawStr())
 	return txHash, nil
 }
+
+// EncodeSignedTransaction encode signed tx to hex string
+func (b *Bridge) EncodeSignedTransaction(signedTx interface{}) (rawTx string, err error) {
+	tx, ok := signedTx.(*types.Transaction)
+	if !ok {
+		return "", errors.New("wrong signed transaction type")
+	}
+	data, err := tx.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+	return common.ToHex(data), nil
+}
+
+var nonceErrorMessages = []string{
+	"nonce too low",
+	"nonce too high",
+	"invalid nonce",
+}
+
+func isNonceError(err error) bool {
+	errMsg := strings.ToLower(err.Error())
+	for _, msg := range nonceErrorMessages {
+		if strings.Contains(errMsg, msg) {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *Bridge) resyncNonceOfSender(tx *types.Transaction) {
+	sender, err := types.Sender(b.Signer, tx)
+	if err != nil {
+		log.Warn("resync nonce get sender failed", "hash", tx.Hash().String(), "err", err)
+		return
+	}
+	nonce, err := b.ResyncNonce(sender.String())
+	if err != nil {
+		log.Warn("resync nonce failed", "account", sender.String(), "err", err)
+		return
+	}
+	log.Info("resync nonce success", "account", sender.String(), "txNonce", tx.Nonce(), "nonce", nonce)
+}
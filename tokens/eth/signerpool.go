@@ -0,0 +1,65 @@
+package eth
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+var errNoPooledSigner = errors.New("no pooled dcrm account has enough balance")
+
+// shouldSelectSigner select pooled signer for swap tx built from 'DcrmAddress'
+// before its nonce is decided (rebuilding with given nonce keep the original sender)
+func (b *Bridge) shouldSelectSigner(tokenCfg *tokens.TokenConfig, args *tokens.BuildTxArgs) bool {
+	if len(tokenCfg.DcrmAddressPool) == 0 || !strings.EqualFold(args.From, tokenCfg.DcrmAddress) {
+		return false
+	}
+	return args.Extra == nil || args.Extra.EthExtra == nil || args.Extra.EthExtra.Nonce == nil
+}
+
+// selectSigner select the dcrm account with enough balance and the lowest pending nonce
+// (return 'DcrmAddress' if 'DcrmAddressPool' is not configed)
+func (b *Bridge) selectSigner(pairID string, requiredValue *big.Int) (string, error) {
+	tokenCfg := b.GetTokenConfig(pairID)
+	if tokenCfg == nil {
+		return "", tokens.ErrUnknownPairID
+	}
+	if len(tokenCfg.DcrmAddressPool) == 0 {
+		return tokenCfg.DcrmAddress, nil
+	}
+	needValue := new(big.Int).Set(defReserveGasFee)
+	if requiredValue != nil && requiredValue.Sign() > 0 {
+		needValue.Add(needValue, requiredValue)
+	}
+	var (
+		selected string
+		minNonce uint64
+	)
+	for _, account := range tokenCfg.GetDcrmAccounts() {
+		balance, err := b.getCoinBalance(account)
+		if err != nil {
+			log.Warn("select signer get balance failed", "pairID", pairID, "account", account, "err", err)
+			continue
+		}
+		if balance.Cmp(needValue) < 0 {
+			continue
+		}
+		nonce, err := b.GetNonce(account, b.GatewayConfig.GetNonceBlockTag())
+		if err != nil {
+			log.Warn("select signer get nonce failed", "pairID", pairID, "account", account, "err", err)
+			continue
+		}
+		if selected == "" || nonce < minNonce {
+			selected = account
+			minNonce = nonce
+		}
+	}
+	if selected == "" {
+		return "", errNoPooledSigner
+	}
+	log.Trace("select signer", "pairID", pairID, "account", selected, "nonce", minNonce)
+	return selected, nil
+}
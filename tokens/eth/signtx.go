@@ -32,6 +32,8 @@ func (b *Bridge) verifyTransactionWithArgs(tx *types.Transaction, args *tokens.B
 	checkReceiver := tokenCfg.ContractAddress
 	if args.SwapType == tokens.SwapoutType && !tokenCfg.IsErc20() {
 		checkReceiver = args.Bind
+	} else if args.SwapType == tokens.SwapoutType && tokenCfg.UseSafeTransfer {
+		checkReceiver = b.ChainConfig.SafeTransferHelper
 	}
 	if !strings.EqualFold(tx.To().String(), checkReceiver) {
 		return fmt.Errorf("[sign] verify tx receiver failed")
@@ -53,11 +55,18 @@ func (b *Bridge) DcrmSignTransaction(rawTx interface{}, args *tokens.BuildTxArgs
 	msgHash := signer.Hash(tx)
 	jsondata, _ := json.Marshal(args)
 	msgContext := string(jsondata)
-	rpcAddr, keyID, err := dcrm.DoSignOne(b.GetDcrmPublicKey(args.PairID), msgHash.String(), msgContext)
+	pubkey := b.GetDcrmPublicKey(args.PairID)
+	if tokenCfg := b.GetTokenConfig(args.PairID); tokenCfg != nil && args.From != "" {
+		pubkey = tokenCfg.GetDcrmPubkeyOf(args.From)
+	}
+	logger := dcrm.NewLogger("chain", b.ChainConfig.BlockChain, "pairID", args.PairID, "txid", args.SwapID, "from", args.From)
+	rpcAddr, keyID, err := dcrm.DoSignOne(pubkey, msgHash.String(), msgContext)
 	if err != nil {
+		logger.Warn("DcrmSignTransaction request failed", "msghash", msgHash.String(), "err", err)
 		return nil, "", err
 	}
-	log.Info(b.ChainConfig.BlockChain+" DcrmSignTransaction start", "keyID", keyID, "msghash", msgHash.String(), "txid", args.SwapID)
+	logger = logger.With("keyID", keyID)
+	logger.Info("DcrmSignTransaction start", "msghash", msgHash.String(), "nonce", tx.Nonce())
 	time.Sleep(retryGetSignStatusInterval)
 
 	var rsv string
@@ -76,19 +85,19 @@ func (b *Bridge) DcrmSignTransaction(rawTx interface{}, args *tokens.BuildTxArgs
 		case dcrm.ErrGetSignStatusFailed, dcrm.ErrGetSignStatusTimeout:
 			return nil, "", err2
 		}
-		log.Warn("retry get sign status as error", "err", err2, "txid", args.SwapID, "keyID", keyID, "bridge", args.Identifier, "swaptype", args.SwapType.String())
+		logger.Warn("retry get sign status as error", "err", err2, "bridge", args.Identifier, "swaptype", args.SwapType.String())
 		time.Sleep(retryGetSignStatusInterval)
 	}
 	if i == retryGetSignStatusCount || rsv == "" {
-		return nil, "", errors.New("get sign status failed")
+		return nil, "", dcrm.ErrGetSignStatusExhausted
 	}
 
-	log.Trace(b.ChainConfig.BlockChain+" DcrmSignTransaction get rsv success", "keyID", keyID, "rsv", rsv)
+	logger.Trace("DcrmSignTransaction get rsv success")
 
 	signature := common.FromHex(rsv)
 
 	if len(signature) != crypto.SignatureLength {
-		log.Error("DcrmSignTransaction wrong length of signature")
+		logger.Error("DcrmSignTransaction wrong length of signature", "length", len(signature))
 		return nil, "", errors.New("wrong signature of keyID " + keyID)
 	}
 
@@ -104,18 +113,27 @@ func (b *Bridge) DcrmSignTransaction(rawTx interface{}, args *tokens.BuildTxArgs
 
 	pairID := args.PairID
 	token := b.GetTokenConfig(pairID)
-	if sender.String() != token.DcrmAddress {
-		log.Error("DcrmSignTransaction verify sender failed", "have", sender.String(), "want", token.DcrmAddress)
+	wantSender := token.DcrmAddress
+	if args.From != "" && token.IsDcrmAccount(args.From) {
+		wantSender = args.From
+	}
+	if !strings.EqualFold(sender.String(), wantSender) {
+		logger.Error("DcrmSignTransaction verify sender failed", "have", sender.String(), "want", wantSender)
 		return nil, "", errors.New("wrong sender address")
 	}
 	txHash = signedTx.Hash().String()
-	log.Info(b.ChainConfig.BlockChain+" DcrmSignTransaction success", "keyID", keyID, "txhash", txHash, "nonce", signedTx.Nonce())
+	logger.Info("DcrmSignTransaction success", "txhash", txHash, "nonce", signedTx.Nonce())
 	return signedTx, txHash, err
 }
 
 // SignTransaction sign tx with pairID
 func (b *Bridge) SignTransaction(rawTx interface{}, pairID string) (signTx interface{}, txHash string, err error) {
-	privKey := b.GetTokenConfig(pairID).GetDcrmAddressPrivateKey()
+	tokenCfg := b.GetTokenConfig(pairID)
+	if tokenCfg.IsKmsSign() {
+		signer := NewKmsSigner(tokenCfg.KmsSignURL, tokenCfg.KmsKeyID, tokenCfg.DcrmAddress)
+		return b.SignTransactionWithDigestSigner(rawTx, signer)
+	}
+	privKey := tokenCfg.GetDcrmAddressPrivateKey()
 	return b.SignTransactionWithPrivateKey(rawTx, privKey)
 }
 
@@ -135,3 +153,21 @@ func (b *Bridge) SignTransactionWithPrivateKey(rawTx interface{}, privKey *ecdsa
 	log.Info(b.ChainConfig.BlockChain+" SignTransaction success", "txhash", txHash, "nonce", signedTx.Nonce())
 	return signedTx, txHash, err
 }
+
+// RecoverSender recover signing address of signed tx with configed chain id
+// (legacy tx signed without chain id before EIP-155 is also supported)
+func (b *Bridge) RecoverSender(tx *types.Transaction) (common.Address, error) {
+	if b.Signer == nil {
+		return common.Address{}, errors.New("signer is not initialized")
+	}
+	return types.Sender(b.Signer, tx)
+}
+
+// RecoverSenderOfRawTx recover signing address of hex encoded signed raw tx
+func (b *Bridge) RecoverSenderOfRawTx(rawTx string) (common.Address, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(common.FromHex(rawTx)); err != nil {
+		return common.Address{}, err
+	}
+	return b.RecoverSender(tx)
+}
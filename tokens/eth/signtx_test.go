@@ -0,0 +1,64 @@
+package eth
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
+	"github.com/anyswap/CrossChain-Bridge/types"
+)
+
+func newRecoverTestBridge(chainID int64) *Bridge {
+	b := NewCrossChainBridge(false)
+	b.Signer = types.MakeSigner("London", big.NewInt(chainID))
+	return b
+}
+
+// test vector from EIP-155 specification
+func TestRecoverSenderEIP155(t *testing.T) {
+	rawTx := "0xf86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83"
+	want := common.HexToAddress("0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F")
+
+	sender, err := newRecoverTestBridge(1).RecoverSenderOfRawTx(rawTx)
+	if err != nil {
+		t.Fatalf("recover sender failed: %v", err)
+	}
+	if sender != want {
+		t.Errorf("recover sender mismatch: want %v, got %v", want.String(), sender.String())
+	}
+
+	// tx protected by chain id 1 can not be recovered with other chain id
+	if _, err = newRecoverTestBridge(5).RecoverSenderOfRawTx(rawTx); err == nil {
+		t.Errorf("recover sender with wrong chain id should fail")
+	}
+}
+
+func TestRecoverSenderPreEIP155(t *testing.T) {
+	key, err := crypto.HexToECDSA("4646464646464646464646464646464646464646464646464646464646464646")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := crypto.PubkeyToAddress(key.PublicKey)
+
+	to := common.HexToAddress("0x3535353535353535353535353535353535353535")
+	tx := types.NewTransaction(9, to, big.NewInt(1e18), 21000, big.NewInt(20e9), nil)
+	signedTx, err := types.SignTx(tx, types.HomesteadSigner{}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signedTx.Protected() {
+		t.Fatalf("homestead signed tx should not be protected")
+	}
+
+	// unprotected tx can be recovered regardless of configed chain id
+	for _, chainID := range []int64{1, 5} {
+		sender, err := newRecoverTestBridge(chainID).RecoverSender(signedTx)
+		if err != nil {
+			t.Fatalf("recover sender failed: %v", err)
+		}
+		if sender != want {
+			t.Errorf("recover sender mismatch: want %v, got %v", want.String(), sender.String())
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package eth
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+)
+
+// StateProvider provide chain states used in building tx (instead of rpc calls)
+type StateProvider interface {
+	GetGasPrice() (*big.Int, error)
+	GetPoolNonce(address string) (uint64, error)
+	GetBalance(address string) (*big.Int, error)
+	GetErc20Balance(contract, address string) (*big.Int, error)
+}
+
+// FixedStateProvider provide fixed chain states (for deterministic tests)
+type FixedStateProvider struct {
+	GasPrice     *big.Int
+	Nonce        uint64
+	Balance      *big.Int
+	TokenBalance *big.Int
+}
+
+// GetGasPrice impl StateProvider
+func (p *FixedStateProvider) GetGasPrice() (*big.Int, error) {
+	return new(big.Int).Set(p.GasPrice), nil
+}
+
+// GetPoolNonce impl StateProvider
+func (p *FixedStateProvider) GetPoolNonce(address string) (uint64, error) {
+	return p.Nonce, nil
+}
+
+// GetBalance impl StateProvider
+func (p *FixedStateProvider) GetBalance(address string) (*big.Int, error) {
+	return new(big.Int).Set(p.Balance), nil
+}
+
+// GetErc20Balance impl StateProvider
+func (p *FixedStateProvider) GetErc20Balance(contract, address string) (*big.Int, error) {
+	return new(big.Int).Set(p.TokenBalance), nil
+}
+
+// SetTestStateProvider enable test mode with state provider (nil to disable).
+// test mode can only be enabled by code and is forbidden on mainnet.
+func (b *Bridge) SetTestStateProvider(provider StateProvider) error {
+	if provider != nil && b.ChainConfig != nil && strings.EqualFold(b.ChainConfig.NetID, netMainnet) {
+		return errors.New("forbid test state provider on mainnet")
+	}
+	if provider != nil {
+		log.Warn("bridge enter test mode with state provider")
+	}
+	b.testStateProvider = provider
+	return nil
+}
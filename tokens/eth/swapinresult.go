@@ -0,0 +1,66 @@
+package eth
+
+import (
+	"math/big"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/types"
+)
+
+// VerifySwapinResult check swapin tx is confirmed, and its receipt status
+// and `LogSwapin` event match the expected account and amount
+// (empty expected account or nil expected amount is not checked).
+// return tokens.ErrTxNotStable if not confirmed yet (caller should retry later)
+func (b *Bridge) VerifySwapinResult(txHash, pairID, expectedAccount string, expectedAmount *big.Int) (*tokens.SwapinResult, error) {
+	tokenCfg := b.GetTokenConfig(pairID)
+	if tokenCfg == nil {
+		return nil, tokens.ErrUnknownPairID
+	}
+	txStatus, err := b.checkTxConfirmed(txHash)
+	if err != nil {
+		return nil, err
+	}
+	receipt, ok := txStatus.Receipt.(*types.RPCTxReceipt)
+	if !ok || receipt == nil || receipt.Status == nil || *receipt.Status != 1 {
+		return nil, tokens.ErrTxWithWrongReceipt
+	}
+	swapinLogs, err := ParseSwapinReceiptLogs(receipt)
+	if err != nil {
+		return nil, err
+	}
+	for _, swapinLog := range swapinLogs {
+		if tokenCfg.ContractAddress != "" && !common.IsEqualIgnoreCase(swapinLog.Contract, tokenCfg.ContractAddress) {
+			continue
+		}
+		if expectedAccount != "" && !common.IsEqualIgnoreCase(swapinLog.Account, expectedAccount) {
+			continue
+		}
+		if expectedAmount != nil && swapinLog.Amount.Cmp(expectedAmount) != 0 {
+			continue
+		}
+		return &tokens.SwapinResult{
+			TxHash:        txHash,
+			PairID:        pairID,
+			BlockHeight:   txStatus.BlockHeight,
+			BlockHash:     txStatus.BlockHash,
+			Confirmations: txStatus.Confirmations,
+			Account:       swapinLog.Account,
+			Amount:        swapinLog.Amount,
+		}, nil
+	}
+	log.Warn("swapin result mismatch", "txHash", txHash, "pairID", pairID,
+		"expectedAccount", expectedAccount, "expectedAmount", expectedAmount, "logs", len(swapinLogs))
+	return nil, tokens.ErrSwapinResultMismatch
+}
+
+// checkTxConfirmed check tx reach the confirmations of chain config (without waiting)
+// return tokens.ErrTxNotStable if not confirmed yet
+func (b *Bridge) checkTxConfirmed(txHash string) (*tokens.TxStatus, error) {
+	txStatus := b.GetTransactionStatus(txHash)
+	if txStatus == nil || txStatus.BlockHeight == 0 || txStatus.Confirmations < *b.ChainConfig.Confirmations {
+		return nil, tokens.ErrTxNotStable
+	}
+	return txStatus, nil
+}
@@ -0,0 +1,48 @@
+package eth
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+func TestVerifySwapinResultNotConfirmed(t *testing.T) {
+	// the tx is not mined yet
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID int `json:"id"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":null}`, req.ID)
+	}))
+	defer server.Close()
+
+	pairID := "testswapinresult"
+	tokens.SetTokenPairsConfig(map[string]*tokens.TokenPairConfig{
+		pairID: {
+			PairID:    pairID,
+			SrcToken:  &tokens.TokenConfig{},
+			DestToken: &tokens.TokenConfig{ContractAddress: testSwapContract},
+		},
+	}, false)
+	defer tokens.SetTokenPairsConfig(nil, false)
+
+	confirmations := uint64(10)
+	b := NewCrossChainBridge(false)
+	b.ChainConfig = &tokens.ChainConfig{Confirmations: &confirmations, AverageBlockTime: 60}
+	b.GatewayConfig = &tokens.GatewayConfig{APIAddress: []string{server.URL}}
+
+	start := time.Now()
+	_, err := b.VerifySwapinResult("0x1234", pairID, "", nil)
+	if err != tokens.ErrTxNotStable {
+		t.Errorf("verify not confirmed swapin: want error %v, got %v", tokens.ErrTxNotStable, err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("verify not confirmed swapin should not wait, elapsed %v", elapsed)
+	}
+}
@@ -0,0 +1,99 @@
+package eth
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/types"
+)
+
+// SwapinLog decoded `LogSwapin` event
+type SwapinLog struct {
+	Contract string
+	TxHash   string
+	Account  string
+	Amount   *big.Int
+}
+
+// ParseSwapinReceiptLogs decode `LogSwapin` events in receipt
+func ParseSwapinReceiptLogs(receipt *types.RPCTxReceipt) ([]*SwapinLog, error) {
+	if receipt == nil {
+		return nil, errors.New("empty tx receipt")
+	}
+	var result []*SwapinLog
+	for _, log := range receipt.Logs {
+		if log.Removed != nil && *log.Removed {
+			continue
+		}
+		if len(log.Topics) != 3 || !bytes.Equal(log.Topics[0].Bytes(), logSwapinTopic) {
+			continue
+		}
+		if log.Data == nil || len(*log.Data) != 32 {
+			return nil, tokens.ErrTxWithWrongLogData
+		}
+		swapinLog := &SwapinLog{
+			TxHash:  log.Topics[1].String(),
+			Account: common.BytesToAddress(log.Topics[2].Bytes()).String(),
+			Amount:  common.GetBigInt(*log.Data, 0, 32),
+		}
+		if log.Address != nil {
+			swapinLog.Contract = log.Address.String()
+		}
+		result = append(result, swapinLog)
+	}
+	return result, nil
+}
+
+// GetSwapinLogs get `LogSwapin` events of swapin tx
+func (b *Bridge) GetSwapinLogs(txHash string) ([]*SwapinLog, error) {
+	receipt, err := b.GetTransactionReceipt(txHash)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status == nil || *receipt.Status != 1 {
+		return nil, tokens.ErrTxWithWrongReceipt
+	}
+	return ParseSwapinReceiptLogs(receipt)
+}
+
+// SumTransferToAccount sum amounts of erc20 `Transfer` events to account in receipt
+func SumTransferToAccount(receipt *types.RPCTxReceipt, account string) (*big.Int, error) {
+	if receipt == nil {
+		return nil, errors.New("empty tx receipt")
+	}
+	total := big.NewInt(0)
+	for _, log := range receipt.Logs {
+		if log.Removed != nil && *log.Removed {
+			continue
+		}
+		// erc721 `Transfer` event has 4 topics (the last one is token id)
+		if len(log.Topics) != 3 || !bytes.Equal(log.Topics[0].Bytes(), erc20CodeParts["LogTransfer"]) {
+			continue
+		}
+		if !common.IsEqualIgnoreCase(common.BytesToAddress(log.Topics[2].Bytes()).String(), account) {
+			continue
+		}
+		if log.Data == nil || len(*log.Data) != 32 {
+			return nil, tokens.ErrTxWithWrongLogData
+		}
+		total.Add(total, common.GetBigInt(*log.Data, 0, 32))
+	}
+	return total, nil
+}
+
+// GetSwapinCreditedAmount get the actual amount credited to account by swapin tx,
+// which is the sum of `Transfer` (including mint) events to account in receipt.
+// it may differ from the swapped value (eg. fee-on-transfer or partial mint).
+func (b *Bridge) GetSwapinCreditedAmount(txHash, account string) (*big.Int, error) {
+	receipt, err := b.GetTransactionReceipt(txHash)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status == nil || *receipt.Status != 1 {
+		return nil, tokens.ErrTxWithWrongReceipt
+	}
+	return SumTransferToAccount(receipt, account)
+}
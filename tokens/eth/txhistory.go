@@ -0,0 +1,147 @@
+package eth
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/common/hexutil"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/types"
+)
+
+const (
+	// eth_getLogs range per request, many providers limit it
+	txHistoryLogsBatchBlocks = 2000
+	// scan native transfers by full blocks is expensive, limit the range
+	maxTxHistoryScanBlocks = 10000
+)
+
+// GetAddressTxHistory best effort list txs involving address in block range [fromBlock, toBlock].
+// erc20 transfers are found by `Transfer` logs (eth_getLogs), which is reliable
+// but may be rejected by providers limiting log queries.
+// native transfers are found by scanning full blocks (at most 'maxTxHistoryScanBlocks' blocks),
+// which only contains direct txs sent by or to address, native value moved by
+// internal calls (eg. contract withdraw) can only be found by trace apis and is missing.
+func (b *Bridge) GetAddressTxHistory(address string, fromBlock, toBlock uint64) ([]*tokens.AddressTxRecord, error) {
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("invalid address %v", address)
+	}
+	if fromBlock > toBlock {
+		return nil, fmt.Errorf("wrong block range [%v, %v]", fromBlock, toBlock)
+	}
+	if toBlock-fromBlock >= maxTxHistoryScanBlocks {
+		return nil, fmt.Errorf("too large block range %v (max %v)", toBlock-fromBlock+1, maxTxHistoryScanBlocks)
+	}
+	account := common.HexToAddress(address)
+
+	records, err := b.getTokenTransferHistory(account, fromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+	nativeRecords, err := b.getNativeTxHistory(account, fromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+	records = append(records, nativeRecords...)
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].BlockNumber < records[j].BlockNumber
+	})
+	return records, nil
+}
+
+// getTokenTransferHistory get erc20 `Transfer` logs from or to account
+func (b *Bridge) getTokenTransferHistory(account common.Address, fromBlock, toBlock uint64) (records []*tokens.AddressTxRecord, err error) {
+	transferTopic := common.BytesToHash(erc20CodeParts["LogTransfer"])
+	accountTopic := common.BytesToHash(account.Bytes())
+	topicsList := [][][]common.Hash{
+		{{transferTopic}, {accountTopic}},      // from account
+		{{transferTopic}, nil, {accountTopic}}, // to account
+	}
+	for start := fromBlock; start <= toBlock; start += txHistoryLogsBatchBlocks {
+		end := start + txHistoryLogsBatchBlocks - 1
+		if end > toBlock {
+			end = toBlock
+		}
+		for _, topics := range topicsList {
+			logs, errf := b.GetLogs(&types.FilterQuery{
+				FromBlock: new(big.Int).SetUint64(start),
+				ToBlock:   new(big.Int).SetUint64(end),
+				Topics:    topics,
+			})
+			if errf != nil {
+				log.Warn("get transfer logs failed", "account", account.String(), "from", start, "to", end, "err", errf)
+				return nil, errf
+			}
+			for _, rlog := range logs {
+				if record := parseTransferLogRecord(rlog); record != nil {
+					records = append(records, record)
+				}
+			}
+		}
+	}
+	return records, nil
+}
+
+func parseTransferLogRecord(rlog *types.RPCLog) *tokens.AddressTxRecord {
+	if rlog.Removed != nil && *rlog.Removed {
+		return nil
+	}
+	// skip erc721 `Transfer` event with 4 topics
+	if len(rlog.Topics) != 3 || rlog.Data == nil || len(*rlog.Data) != 32 {
+		return nil
+	}
+	if rlog.TxHash == nil || rlog.BlockNumber == nil || rlog.Address == nil {
+		return nil
+	}
+	return &tokens.AddressTxRecord{
+		TxHash:      rlog.TxHash.Hex(),
+		BlockNumber: uint64(*rlog.BlockNumber),
+		From:        common.BytesToAddress(rlog.Topics[1].Bytes()).String(),
+		To:          common.BytesToAddress(rlog.Topics[2].Bytes()).String(),
+		Token:       rlog.Address.String(),
+		Value:       common.GetBigInt(*rlog.Data, 0, 32),
+	}
+}
+
+// getNativeTxHistory scan full blocks for txs sent by or to account
+func (b *Bridge) getNativeTxHistory(account common.Address, fromBlock, toBlock uint64) (records []*tokens.AddressTxRecord, err error) {
+	for height := fromBlock; height <= toBlock; height++ {
+		var block *struct {
+			Transactions []*types.RPCTransaction `json:"transactions"`
+		}
+		err = b.CallRPC(&block, "eth_getBlockByNumber", hexutil.Uint64(height), true)
+		if err != nil {
+			log.Warn("get full block failed", "height", height, "err", err)
+			return nil, err
+		}
+		if block == nil {
+			return nil, fmt.Errorf("block %v not found", height)
+		}
+		for _, tx := range block.Transactions {
+			isFrom := tx.From != nil && *tx.From == account
+			isTo := tx.Recipient != nil && *tx.Recipient == account
+			if !isFrom && !isTo {
+				continue
+			}
+			record := &tokens.AddressTxRecord{
+				TxHash:      tx.Hash.Hex(),
+				BlockNumber: height,
+				Value:       big.NewInt(0),
+			}
+			if tx.From != nil {
+				record.From = tx.From.String()
+			}
+			if tx.Recipient != nil {
+				record.To = tx.Recipient.String()
+			}
+			if tx.Amount != nil {
+				record.Value = tx.Amount.ToInt()
+			}
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
@@ -0,0 +1,122 @@
+package eth
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/rpc/client"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/types"
+)
+
+// txpool_inspect result: status -> address -> nonce -> summary
+type txPoolInspect map[string]map[string]map[string]string
+
+// txpool_content result: status -> address -> nonce -> tx
+type txPoolContent map[string]map[string]map[string]*types.RPCTransaction
+
+// GetTxPoolStatus get pending and queued txs count of address in txpool by `txpool_inspect`.
+// queued txs are waiting for missing nonces, so a high queued count signals a nonce gap.
+// return tokens.ErrTxPoolNotSupported if gateways do not support the txpool namespace.
+func (b *Bridge) GetTxPoolStatus(address string) (pending, queued uint64, err error) {
+	gateway := b.GatewayConfig
+	var result txPoolInspect
+	unsupported := true
+	for _, apiAddress := range gateway.APIAddress {
+		url := apiAddress
+		err = client.RPCPost(&result, url, "txpool_inspect")
+		if err == nil {
+			pending = countTxsOfAddress(result["pending"], address)
+			queued = countTxsOfAddress(result["queued"], address)
+			return pending, queued, nil
+		}
+		if !isMethodNotSupportedError(err) {
+			unsupported = false
+		}
+	}
+	if unsupported && err != nil {
+		return 0, 0, tokens.ErrTxPoolNotSupported
+	}
+	return 0, 0, err
+}
+
+// GetDuplicateNonces get nonces of address with more than one tx in the txpools of all gateways
+// by `txpool_content`. each node keeps only one tx per nonce, but different nodes may hold
+// different txs of the same nonce (only one of them can be mined).
+// return tokens.ErrTxPoolNotSupported if gateways do not support the txpool namespace.
+func (b *Bridge) GetDuplicateNonces(address string) (map[uint64][]string, error) {
+	gateway := b.GatewayConfig
+	nonceTxs := make(map[uint64][]string)
+	var err error
+	unsupported := true
+	succeed := false
+	for _, apiAddress := range gateway.APIAddress {
+		url := apiAddress
+		var result txPoolContent
+		err = client.RPCPost(&result, url, "txpool_content")
+		if err != nil {
+			if !isMethodNotSupportedError(err) {
+				unsupported = false
+			}
+			continue
+		}
+		succeed = true
+		for _, status := range []string{"pending", "queued"} {
+			for addr, txs := range result[status] {
+				if !strings.EqualFold(addr, address) {
+					continue
+				}
+				for nonceStr, tx := range txs {
+					nonce, errp := strconv.ParseUint(nonceStr, 10, 64)
+					if errp != nil || tx == nil || tx.Hash == nil {
+						continue
+					}
+					nonceTxs[nonce] = appendIfNotExist(nonceTxs[nonce], tx.Hash.Hex())
+				}
+			}
+		}
+	}
+	if !succeed {
+		if unsupported && err != nil {
+			return nil, tokens.ErrTxPoolNotSupported
+		}
+		return nil, err
+	}
+	duplicates := make(map[uint64][]string)
+	for nonce, txHashes := range nonceTxs {
+		if len(txHashes) > 1 {
+			sort.Strings(txHashes)
+			duplicates[nonce] = txHashes
+		}
+	}
+	return duplicates, nil
+}
+
+func appendIfNotExist(list []string, item string) []string {
+	for _, s := range list {
+		if strings.EqualFold(s, item) {
+			return list
+		}
+	}
+	return append(list, item)
+}
+
+func countTxsOfAddress(txs map[string]map[string]string, address string) uint64 {
+	for addr, nonceTxs := range txs {
+		if strings.EqualFold(addr, address) {
+			return uint64(len(nonceTxs))
+		}
+	}
+	return 0
+}
+
+func isMethodNotSupportedError(err error) bool {
+	errMsg := strings.ToLower(err.Error())
+	for _, substr := range []string{"method not found", "does not exist", "not available", "not supported"} {
+		if strings.Contains(errMsg, substr) {
+			return true
+		}
+	}
+	return false
+}
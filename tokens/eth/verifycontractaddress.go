@@ -10,21 +10,37 @@ import (
 	"github.com/anyswap/CrossChain-Bridge/tokens/btc"
 )
 
+// swap event signature hashes
+const (
+	// `Keccak256Hash([]byte("LogSwapin(bytes32,address,uint256)"))`
+	LogSwapinTopicHash = "0x05d0634fe981be85c22e2942a880821b70095d84e152c3ea3c17a4e4250d9d61"
+	// `Keccak256Hash([]byte("LogSwapout(address,uint256,string)"))`
+	MbtcLogSwapoutTopicHash = "0x9c92ad817e5474d30a4378deface765150479363a897b0590fbb12ae9d89396b"
+	// `Keccak256Hash([]byte("LogSwapout(address,address,uint256)"))`
+	MethLogSwapoutTopicHash = "0x6b616089d04950dc06c45c6dd787d657980543f89651aec47924752c7d16c888"
+)
+
 var (
 	// ExtCodeParts extended func hashes and log topics
 	ExtCodeParts map[string][]byte
 
 	// first 4 bytes of `Keccak256Hash([]byte("Swapin(bytes32,address,uint256)"))`
 	swapinFuncHash = common.FromHex("0xec126c77")
-	logSwapinTopic = common.FromHex("0x05d0634fe981be85c22e2942a880821b70095d84e152c3ea3c17a4e4250d9d61")
+	logSwapinTopic = common.FromHex(LogSwapinTopicHash)
 
 	// first 4 bytes of `Keccak256Hash([]byte("Swapout(uint256,string)"))`
 	mBTCSwapoutFuncHash = common.FromHex("0xad54056d")
-	mBTCLogSwapoutTopic = common.FromHex("0x9c92ad817e5474d30a4378deface765150479363a897b0590fbb12ae9d89396b")
+	mBTCLogSwapoutTopic = common.FromHex(MbtcLogSwapoutTopicHash)
 
 	// first 4 bytes of `Keccak256Hash([]byte("Swapout(uint256,address)"))`
 	mETHSwapoutFuncHash = common.FromHex("0x628d6cba")
-	mETHLogSwapoutTopic = common.FromHex("0x6b616089d04950dc06c45c6dd787d657980543f89651aec47924752c7d16c888")
+	mETHLogSwapoutTopic = common.FromHex(MethLogSwapoutTopicHash)
+
+	// first 4 bytes of `Keccak256Hash([]byte("safeTransfer(address,address,uint256)"))`
+	safeTransferFuncHash = common.FromHex("0xd1660f99")
+
+	// first 4 bytes of `Keccak256Hash([]byte("batchTransfer(address,address[],uint256[])"))`
+	batchTransferFuncHash = common.FromHex("0x1239ec8c")
 )
 
 var mBTCExtCodeParts = map[string][]byte{
@@ -45,17 +61,18 @@ var mETHExtCodeParts = map[string][]byte{
 
 var erc20CodeParts = map[string][]byte{
 	// Erc20 interfaces
-	"name":         common.FromHex("0x06fdde03"),
-	"symbol":       common.FromHex("0x95d89b41"),
-	"decimals":     common.FromHex("0x313ce567"),
-	"totalSupply":  common.FromHex("0x18160ddd"),
-	"balanceOf":    common.FromHex("0x70a08231"),
-	"transfer":     common.FromHex("0xa9059cbb"),
-	"transferFrom": common.FromHex("0x23b872dd"),
-	"approve":      common.FromHex("0x095ea7b3"),
-	"allowance":    common.FromHex("0xdd62ed3e"),
-	"LogTransfer":  common.FromHex("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"),
-	"LogApproval":  common.FromHex("0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925"),
+	"name":            common.FromHex("0x06fdde03"),
+	"symbol":          common.FromHex("0x95d89b41"),
+	"decimals":        common.FromHex("0x313ce567"),
+	"totalSupply":     common.FromHex("0x18160ddd"),
+	"balanceOf":       common.FromHex("0x70a08231"),
+	"transfer":        common.FromHex("0xa9059cbb"),
+	"transferFrom":    common.FromHex("0x23b872dd"),
+	"approve":         common.FromHex("0x095ea7b3"),
+	"transferAndCall": common.FromHex("0x4000aea0"),
+	"allowance":       common.FromHex("0xdd62ed3e"),
+	"LogTransfer":     common.FromHex("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"),
+	"LogApproval":     common.FromHex("0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925"),
 }
 
 func (b *Bridge) getContractCode(contract string) (code []byte, err error) {
@@ -151,10 +168,6 @@ func isMbtcSwapout() bool {
 	return btc.BridgeInstance != nil
 }
 
-func getSwapinFuncHash() []byte {
-	return ExtCodeParts["SwapinFuncHash"]
-}
-
 func getSwapoutFuncHash() []byte {
 	return ExtCodeParts["SwapoutFuncHash"]
 }
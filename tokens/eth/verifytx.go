@@ -207,8 +207,7 @@ func (b *Bridge) getStableReceipt(swapInfo *tokens.TxSwapInfo) (*types.RPCTxRece
 	if *receipt.Status != 1 {
 		return nil, tokens.ErrTxWithWrongReceipt
 	}
-	if txStatus.BlockHeight == 0 ||
-		txStatus.Confirmations < *b.GetChainConfig().Confirmations {
+	if !b.IsTransactionFinal(txStatus) {
 		return nil, tokens.ErrTxNotStable
 	}
 	return receipt, nil
@@ -0,0 +1,56 @@
+package tokens
+
+import (
+	"math/big"
+	"strings"
+	"sync"
+)
+
+var (
+	feesSpent     = make(map[string]*big.Int)
+	feesSpentLock sync.RWMutex
+)
+
+func getFeesSpentKey(pairID string, isSrc bool) string {
+	if isSrc {
+		return strings.ToLower(pairID) + ":src"
+	}
+	return strings.ToLower(pairID) + ":dst"
+}
+
+// AddFeesSpent add gas fee spent by swap tx of pair
+func AddFeesSpent(pairID string, isSrc bool, fee *big.Int) {
+	if fee == nil || fee.Sign() <= 0 {
+		return
+	}
+	key := getFeesSpentKey(pairID, isSrc)
+	feesSpentLock.Lock()
+	defer feesSpentLock.Unlock()
+	if total, exist := feesSpent[key]; exist {
+		total.Add(total, fee)
+	} else {
+		feesSpent[key] = new(big.Int).Set(fee)
+	}
+}
+
+// SetFeesSpent set gas fee spent of pair (eg. restore from database)
+func SetFeesSpent(pairID string, isSrc bool, fee *big.Int) {
+	if fee == nil {
+		return
+	}
+	key := getFeesSpentKey(pairID, isSrc)
+	feesSpentLock.Lock()
+	defer feesSpentLock.Unlock()
+	feesSpent[key] = new(big.Int).Set(fee)
+}
+
+// GetFeesSpent get cumulative gas fee spent by swap txs of pair
+func GetFeesSpent(pairID string, isSrc bool) *big.Int {
+	key := getFeesSpentKey(pairID, isSrc)
+	feesSpentLock.RLock()
+	defer feesSpentLock.RUnlock()
+	if total, exist := feesSpent[key]; exist {
+		return new(big.Int).Set(total)
+	}
+	return big.NewInt(0)
+}
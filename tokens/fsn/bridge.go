@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/rpc/client"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
 	"github.com/anyswap/CrossChain-Bridge/tokens/eth"
 	"github.com/anyswap/CrossChain-Bridge/types"
@@ -83,7 +84,28 @@ func (b *Bridge) VerifyChainID() {
 		log.Fatalf("unsupported fusion network %v", networkID)
 	}
 
-	b.Signer = types.MakeSigner("EIP155", chainID)
+	b.AssertExpectedChainID()
+	b.Signer = types.MakeSigner("London", chainID)
 
 	log.Info("VerifyChainID succeed", "networkID", networkID, "chainID", chainID)
 }
+
+// GetTransactionAndReceiptConsistent get tx and receipt in one call and check their consistency
+func (b *Bridge) GetTransactionAndReceiptConsistent(txHash string) (tx *types.RPCTransaction, receipt *types.RPCTxReceipt, consistent bool, err error) {
+	return b.GetTransactionAndReceiptConsistentBy(txHash, getTxAndReceipt)
+}
+
+func getTxAndReceipt(url, txHash string) (*types.RPCTransaction, *types.RPCTxReceipt, error) {
+	var result *types.RPCTxAndReceipt
+	err := client.RPCPost(&result, url, "fsn_getTransactionAndReceipt", txHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	if result == nil {
+		return nil, nil, nil
+	}
+	if result.ReceiptFound != nil && !*result.ReceiptFound {
+		return result.Tx, nil, nil
+	}
+	return result.Tx, result.Receipt, nil
+}
@@ -0,0 +1,91 @@
+package tokens
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// MaxConfigGasPriceGwei configed gas prices above this (in gwei) are rejected as fat-finger mistakes
+const MaxConfigGasPriceGwei = 10000
+
+var maxConfigGasPrice = GweiToWei(MaxConfigGasPriceGwei)
+
+// GweiToWei convert gas price in gwei to wei
+func GweiToWei(gwei float64) *big.Int {
+	return ToBits(gwei, 9)
+}
+
+// checkGasPriceConfig check gas price configed in wei or in gwei (not both)
+func checkGasPriceConfig(name string, wei uint64, gwei float64) error {
+	if wei > 0 && gwei > 0 {
+		return fmt.Errorf("can not config both '%v' and '%vGwei'", name, name)
+	}
+	if gwei < 0 {
+		return fmt.Errorf("negative '%vGwei'", name)
+	}
+	if gwei > MaxConfigGasPriceGwei {
+		return fmt.Errorf("too large '%vGwei' %v (must be at most %v gwei)", name, gwei, MaxConfigGasPriceGwei)
+	}
+	if new(big.Int).SetUint64(wei).Cmp(maxConfigGasPrice) > 0 {
+		return fmt.Errorf("too large '%v' %v wei (must be at most %v gwei)", name, wei, MaxConfigGasPriceGwei)
+	}
+	return nil
+}
+
+// getGasPrice get gas price configed in gwei or in wei, return nil if not configed
+func getGasPrice(wei uint64, gwei float64) *big.Int {
+	if gwei > 0 {
+		return GweiToWei(gwei)
+	}
+	if wei > 0 {
+		return new(big.Int).SetUint64(wei)
+	}
+	return nil
+}
+
+func (c *TokenConfig) checkGasPriceConfig() error {
+	if err := checkGasPriceConfig("FixedGasPrice", c.FixedGasPrice, c.FixedGasPriceGwei); err != nil {
+		return err
+	}
+	if err := checkGasPriceConfig("FallbackGasPrice", c.FallbackGasPrice, c.FallbackGasPriceGwei); err != nil {
+		return err
+	}
+	if err := checkGasPriceConfig("MinGasPrice", 0, c.MinGasPriceGwei); err != nil {
+		return err
+	}
+	if err := checkGasPriceConfig("MaxGasPrice", 0, c.MaxGasPriceGwei); err != nil {
+		return err
+	}
+	if c.MaxGasPriceGwei > 0 && c.MinGasPriceGwei > c.MaxGasPriceGwei {
+		return fmt.Errorf("'MinGasPriceGwei' %v is larger than 'MaxGasPriceGwei' %v", c.MinGasPriceGwei, c.MaxGasPriceGwei)
+	}
+	return nil
+}
+
+// GetFixedGasPrice get fixed gas price in wei ('FixedGasPriceGwei' or 'FixedGasPrice'), nil if not configed
+func (c *TokenConfig) GetFixedGasPrice() *big.Int {
+	return getGasPrice(c.FixedGasPrice, c.FixedGasPriceGwei)
+}
+
+// GetFallbackGasPrice get fallback gas price in wei ('FallbackGasPriceGwei' or 'FallbackGasPrice'), nil if not configed
+func (c *TokenConfig) GetFallbackGasPrice() *big.Int {
+	return getGasPrice(c.FallbackGasPrice, c.FallbackGasPriceGwei)
+}
+
+// GetMinGasPrice get gas price floor in wei, nil if not configed
+func (c *TokenConfig) GetMinGasPrice() *big.Int {
+	return getGasPrice(0, c.MinGasPriceGwei)
+}
+
+// GetMaxGasPrice get gas price cap in wei, nil if not configed
+func (c *TokenConfig) GetMaxGasPrice() *big.Int {
+	return getGasPrice(0, c.MaxGasPriceGwei)
+}
+
+// GetDefaultGasTipCap get default gas tip cap in wei ('DefaultGasTipCapGwei' or 'DefaultGasTipCap')
+func (c *ChainConfig) GetDefaultGasTipCap() *big.Int {
+	if tip := getGasPrice(c.DefaultGasTipCap, c.DefaultGasTipCapGwei); tip != nil {
+		return tip
+	}
+	return new(big.Int)
+}
@@ -36,6 +36,30 @@ var (
 	ErrSwapoutLogNotFound   = errors.New("swapout log not found or removed")
 	ErrUnknownPairID        = errors.New("unknown pair ID")
 	ErrBindAddressMismatch  = errors.New("bind address mismatch")
+	ErrTxWithWrongNonce     = errors.New("tx with wrong nonce")
+	ErrRateLimited          = errors.New("swap rate limited")
+	ErrBindAddressChecksum  = errors.New("bind address checksum mismatch")
+	ErrTxDataTooLarge       = errors.New("tx data too large")
+	ErrPairDisabled         = errors.New("token pair is disabled")
+	ErrSwapinCompleted      = errors.New("swapin is already completed on chain")
+	ErrWrongChain           = errors.New("gateway chain id mismatch")
+	ErrAggregateBalance     = errors.New("not enough balance for all swaps")
+	ErrZeroSwapValue        = errors.New("swapped value is zero")
+	ErrTxExpired            = errors.New("tx is expired")
+	ErrTxPoolNotSupported   = errors.New("txpool namespace not supported")
+	ErrTxPendingTooLong     = errors.New("tx is pending too long")
+	ErrSwapinResultMismatch = errors.New("swapin result mismatch")
+	ErrSwapTxNotReverted    = errors.New("swap tx is not reverted")
+	ErrTxAlreadyMined       = errors.New("tx is already mined")
+	ErrGasLimitExceedsBlock = errors.New("gas limit exceeds block gas limit")
+	ErrTxDropped            = errors.New("tx is dropped and its nonce is consumed")
+	ErrSigningFailed        = errors.New("dcrm signing failed")
+
+	ErrSwapinToReservedAddress = errors.New("swapin to zero, contract or dcrm address")
+	ErrSwapExceedsValueLimit   = errors.New("swap value exceeds usd limit")
+	ErrRecipientBlacklisted    = errors.New("recipient is blacklisted by token")
+	ErrBelowMinTransfer        = errors.New("swap value is below min transfer amount")
+	ErrContractPaused          = errors.New("contract is paused")
 
 	// errors should register
 	ErrTxWithWrongMemo       = errors.New("tx with wrong memo")
@@ -101,8 +125,75 @@ type CrossChainBridge interface {
 
 // NonceSetter interface (for eth-like)
 type NonceSetter interface {
-	GetPoolNonce(address, height string) (uint64, error)
+	GetNonce(address, blockTag string) (uint64, error)
 	SetNonce(pairID string, value uint64)
 	AdjustNonce(pairID string, value uint64) (nonce uint64)
 	IncreaseNonce(pairID string, value uint64)
 }
+
+// TxFeeCalculator interface (for eth-like)
+type TxFeeCalculator interface {
+	CalcTxFee(txHash string, receipt interface{}) (*big.Int, error)
+}
+
+// ChainIDChecker interface (for eth-like)
+type ChainIDChecker interface {
+	CheckChainID() error
+}
+
+// AggregateBalanceChecker interface (check balance once for a batch of swaps)
+type AggregateBalanceChecker interface {
+	CheckAggregateBalance(pairID string, amounts []*big.Int) error
+}
+
+// SwapinResultVerifier interface (verify minted result of swapin tx)
+type SwapinResultVerifier interface {
+	VerifySwapinResult(txHash, pairID, expectedAccount string, expectedAmount *big.Int) (*SwapinResult, error)
+}
+
+// RefundBuilder interface (build tx to refund reverted swapout)
+type RefundBuilder interface {
+	IsTxReverted(txHash string) (bool, error)
+	BuildRefundTx(args *BuildTxArgs) (rawTx interface{}, err error)
+}
+
+// BatchSwapoutBuilder interface (build one tx for a batch of swapouts)
+type BatchSwapoutBuilder interface {
+	BuildBatchSwapoutTx(args []*BuildTxArgs) (rawTx interface{}, err error)
+}
+
+// DuplicateNonceDetector interface (diagnose txs sharing one nonce in txpool)
+type DuplicateNonceDetector interface {
+	GetDuplicateNonces(address string) (map[uint64][]string, error)
+}
+
+// TxDropDetector interface (detect sent tx dropped from mempool)
+type TxDropDetector interface {
+	CheckTxDropped(txHash, from string, nonce uint64) error
+}
+
+// CongestionLevelGetter interface (network congestion signal)
+type CongestionLevelGetter interface {
+	GetCongestionLevel() (CongestionLevel, error)
+}
+
+// FinalityProvider interface (finality signal of chain, see 'FinalityMode' of chain config)
+type FinalityProvider interface {
+	IsBlockFinal(txStatus *TxStatus) (bool, error)
+}
+
+// AddressTxHistoryGetter interface (best effort, for reconciliation of address)
+type AddressTxHistoryGetter interface {
+	GetAddressTxHistory(address string, fromBlock, toBlock uint64) ([]*AddressTxRecord, error)
+}
+
+// ContractPausedChecker interface
+type ContractPausedChecker interface {
+	IsContractPaused(address string) (bool, error)
+}
+
+// RawTxSender interface (for resubmitting persisted signed tx)
+type RawTxSender interface {
+	EncodeSignedTransaction(signedTx interface{}) (rawTx string, err error)
+	SendRawTransactionHex(rawTx string) (txHash string, err error)
+}
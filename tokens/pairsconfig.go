@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/BurntSushi/toml"
 	"github.com/anyswap/CrossChain-Bridge/common"
@@ -17,13 +19,43 @@ var (
 	tokenPairsConfigDirectory string
 
 	tokenPairsConfig map[string]*TokenPairConfig
+
+	disabledPairs     = make(map[string]bool)
+	disabledPairsLock sync.RWMutex
 )
 
 // TokenPairConfig pair config
 type TokenPairConfig struct {
 	PairID    string
+	Disabled  bool `json:",omitempty"` // circuit breaker of building swap tx
 	SrcToken  *TokenConfig
 	DestToken *TokenConfig
+
+	// name of registered swap value calculator (default to 'Default')
+	SwapValueCalculator string `json:",omitempty"`
+	// rounding of default calculator when converting between differing decimals,
+	// one of 'floor', 'ceil' and 'half' (default is empty which does not convert decimals)
+	AmountRounding RoundingMode `json:",omitempty"`
+}
+
+// IsPairDisabled is pair disabled
+func IsPairDisabled(pairID string) bool {
+	disabledPairsLock.RLock()
+	defer disabledPairsLock.RUnlock()
+	return disabledPairs[strings.ToLower(pairID)]
+}
+
+// SetPairDisabled enable or disable pair
+func SetPairDisabled(pairID string, disabled bool) {
+	disabledPairsLock.Lock()
+	defer disabledPairsLock.Unlock()
+	pairID = strings.ToLower(pairID)
+	if disabled {
+		disabledPairs[pairID] = true
+	} else {
+		delete(disabledPairs, pairID)
+	}
+	log.Info("set pair disabled flag", "pairID", pairID, "disabled", disabled)
 }
 
 // SetTokenPairsDir set token pairs directory
@@ -53,6 +85,9 @@ func SetTokenPairsConfig(pairsConfig map[string]*TokenPairConfig, check bool) {
 		}
 	}
 	tokenPairsConfig = pairsConfig
+	for _, pairCfg := range pairsConfig {
+		SetPairDisabled(pairCfg.PairID, pairCfg.Disabled)
+	}
 }
 
 // GetTokenPairsConfig get token pairs config
@@ -184,6 +219,67 @@ func checkTokenPairsConfig(pairsConfig map[string]*TokenPairConfig) (err error)
 	return nil
 }
 
+// ValidateAllTokenConfigs validate consistency of all token pairs config
+func ValidateAllTokenConfigs() []error {
+	return validateTokenPairsConfig(tokenPairsConfig)
+}
+
+func validateTokenPairsConfig(pairsConfig map[string]*TokenPairConfig) (errs []error) {
+	keys := make([]string, 0, len(pairsConfig))
+	for key := range pairsConfig {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	srcDecimals := make(map[string]uint8)
+	dstDecimals := make(map[string]uint8)
+	srcDcrmPubkeys := make(map[string]string)
+	dstDcrmPubkeys := make(map[string]string)
+	for _, key := range keys {
+		pairCfg := pairsConfig[key]
+		if !strings.EqualFold(key, pairCfg.PairID) {
+			errs = append(errs, fmt.Errorf("pairID '%v' mismatch with its key '%v'", pairCfg.PairID, key))
+		}
+		if pairCfg.SrcToken != nil {
+			errs = append(errs, validateTokenConfig(pairCfg.PairID, pairCfg.SrcToken, srcDecimals, srcDcrmPubkeys)...)
+		}
+		if pairCfg.DestToken != nil {
+			errs = append(errs, validateTokenConfig(pairCfg.PairID, pairCfg.DestToken, dstDecimals, dstDcrmPubkeys)...)
+		}
+	}
+	return errs
+}
+
+func validateTokenConfig(pairID string, tokenCfg *TokenConfig, contractDecimals map[string]uint8, dcrmPubkeys map[string]string) (errs []error) {
+	const maxDefaultGasLimit = uint64(10000000)
+	if tokenCfg.DcrmAddress == "" {
+		errs = append(errs, fmt.Errorf("pairID '%v' has empty dcrm address", pairID))
+	} else if tokenCfg.DcrmPubkey != "" {
+		dcrmAddress := strings.ToLower(tokenCfg.DcrmAddress)
+		if pubkey, exist := dcrmPubkeys[dcrmAddress]; exist && !strings.EqualFold(pubkey, tokenCfg.DcrmPubkey) {
+			errs = append(errs, fmt.Errorf("pairID '%v' dcrm address '%v' has conflicting public keys", pairID, tokenCfg.DcrmAddress))
+		}
+		dcrmPubkeys[dcrmAddress] = tokenCfg.DcrmPubkey
+	}
+	if tokenCfg.ContractAddress != "" && tokenCfg.Decimals != nil {
+		contract := strings.ToLower(tokenCfg.ContractAddress)
+		if decimals, exist := contractDecimals[contract]; exist && decimals != *tokenCfg.Decimals {
+			errs = append(errs, fmt.Errorf("pairID '%v' contract '%v' has conflicting decimals %v and %v", pairID, tokenCfg.ContractAddress, decimals, *tokenCfg.Decimals))
+		}
+		contractDecimals[contract] = *tokenCfg.Decimals
+	}
+	if tokenCfg.DefaultGasLimit > maxDefaultGasLimit {
+		errs = append(errs, fmt.Errorf("pairID '%v' has too large 'DefaultGasLimit' %v", pairID, tokenCfg.DefaultGasLimit))
+	}
+	if tokenCfg.SwapinGasLimit > maxDefaultGasLimit {
+		errs = append(errs, fmt.Errorf("pairID '%v' has too large 'SwapinGasLimit' %v", pairID, tokenCfg.SwapinGasLimit))
+	}
+	if tokenCfg.SwapoutGasLimit > maxDefaultGasLimit {
+		errs = append(errs, fmt.Errorf("pairID '%v' has too large 'SwapoutGasLimit' %v", pairID, tokenCfg.SwapoutGasLimit))
+	}
+	return errs
+}
+
 // CheckConfig check token pair config
 func (c *TokenPairConfig) CheckConfig() (err error) {
 	if c.PairID == "" {
@@ -195,6 +291,12 @@ func (c *TokenPairConfig) CheckConfig() (err error) {
 	if c.DestToken == nil {
 		return errors.New("tokenPair must config 'DestToken'")
 	}
+	if _, err = getSwapValueCalculatorByName(c.SwapValueCalculator); err != nil {
+		return err
+	}
+	if !c.AmountRounding.IsValid() {
+		return fmt.Errorf("unknown 'AmountRounding' '%v'", c.AmountRounding)
+	}
 	err = c.SrcToken.CheckConfig(true)
 	if err != nil {
 		return err
@@ -239,7 +341,11 @@ func LoadTokenPairsConfigInDir(dir string, check bool) (map[string]*TokenPairCon
 			return nil, err
 		}
 		// use all small case to identify
-		pairsConfig[strings.ToLower(pairConfig.PairID)] = pairConfig
+		pairID := strings.ToLower(pairConfig.PairID)
+		if _, exist := pairsConfig[pairID]; exist {
+			return nil, fmt.Errorf("duplicate pairID '%v' in file '%v'", pairConfig.PairID, fileName)
+		}
+		pairsConfig[pairID] = pairConfig
 	}
 	if check {
 		err = checkTokenPairsConfig(pairsConfig)
@@ -276,12 +382,18 @@ func AddPairConfig(configFile string) (pairConfig *TokenPairConfig, err error) {
 	if err != nil {
 		return nil, err
 	}
+	// only reload disabled flag of existing pair
+	if IsTokenPairExist(pairConfig.PairID) {
+		SetPairDisabled(pairConfig.PairID, pairConfig.Disabled)
+		return pairConfig, nil
+	}
 	err = checkAddTokenPairsConfig(pairConfig)
 	if err != nil {
 		return nil, err
 	}
 	// use all small case to identify
 	tokenPairsConfig[strings.ToLower(pairConfig.PairID)] = pairConfig
+	SetPairDisabled(pairConfig.PairID, pairConfig.Disabled)
 	log.Info("add pair config success", "pairID", pairConfig.PairID, "configFile", configFile)
 	return pairConfig, nil
 }
@@ -0,0 +1,72 @@
+package tokens
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+)
+
+// PriceOracle estimate USD value of amount (in smallest unit) of token of pair
+type PriceOracle interface {
+	GetUSDValue(pairID string, amount *big.Int, isSrc bool) (float64, error)
+}
+
+var (
+	priceOracle     PriceOracle
+	priceOracleLock sync.RWMutex
+
+	errNoPriceOracle = errors.New("price oracle is not registered")
+)
+
+// RegisterPriceOracle register price oracle used by 'MaxSwapUSD' check
+func RegisterPriceOracle(oracle PriceOracle) {
+	priceOracleLock.Lock()
+	defer priceOracleLock.Unlock()
+	priceOracle = oracle
+}
+
+func getPriceOracle() PriceOracle {
+	priceOracleLock.RLock()
+	defer priceOracleLock.RUnlock()
+	return priceOracle
+}
+
+// IsSwapExceedsUSDLimit check whether swap value exceeds 'MaxSwapUSD' of token config
+// ('tokenCfg' is of the receiving side, 'isSrc' is the side of 'value')
+func IsSwapExceedsUSDLimit(tokenCfg *TokenConfig, pairID string, value *big.Int, isSrc bool) (bool, error) {
+	if tokenCfg == nil || tokenCfg.MaxSwapUSD <= 0 || value == nil {
+		return false, nil
+	}
+	oracle := getPriceOracle()
+	if oracle == nil {
+		return false, errNoPriceOracle
+	}
+	usdValue, err := oracle.GetUSDValue(pairID, value, isSrc)
+	if err != nil {
+		log.Warn("get usd value from price oracle failed", "pairID", pairID, "value", value, "err", err)
+		return false, err
+	}
+	if usdValue > tokenCfg.MaxSwapUSD {
+		log.Warn("swap value exceeds usd limit", "pairID", pairID, "value", value, "usdValue", usdValue, "maxSwapUSD", tokenCfg.MaxSwapUSD)
+		return true, nil
+	}
+	return false, nil
+}
+
+// CheckSwapUSDValue return ErrSwapExceedsValueLimit if swap value exceeds 'MaxSwapUSD'
+// (skipped if 'MaxSwapUSDManualReview' as it is checked when verifying swap)
+func CheckSwapUSDValue(tokenCfg *TokenConfig, pairID string, value *big.Int, isSrc bool) error {
+	if tokenCfg == nil || tokenCfg.MaxSwapUSDManualReview {
+		return nil
+	}
+	exceeds, err := IsSwapExceedsUSDLimit(tokenCfg, pairID, value, isSrc)
+	if err != nil {
+		return err
+	}
+	if exceeds {
+		return ErrSwapExceedsValueLimit
+	}
+	return nil
+}
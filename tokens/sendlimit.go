@@ -0,0 +1,46 @@
+package tokens
+
+import (
+	"sync/atomic"
+)
+
+var (
+	// limit in-flight sending txs across all pairs (unlimited if nil)
+	sendSemaphore chan struct{}
+
+	sendInFlight int64
+	sendQueued   int64
+)
+
+// SetMaxConcurrentSends set max in-flight sending txs (0 means unlimited),
+// should be called at initialization before any sending
+func SetMaxConcurrentSends(maxSends int) {
+	if maxSends <= 0 {
+		sendSemaphore = nil
+		return
+	}
+	sendSemaphore = make(chan struct{}, maxSends)
+}
+
+// AcquireSendSlot wait until a send slot is available,
+// call the returned release func after sending finished
+func AcquireSendSlot() (release func()) {
+	sem := sendSemaphore
+	if sem != nil {
+		atomic.AddInt64(&sendQueued, 1)
+		sem <- struct{}{}
+		atomic.AddInt64(&sendQueued, -1)
+	}
+	atomic.AddInt64(&sendInFlight, 1)
+	return func() {
+		atomic.AddInt64(&sendInFlight, -1)
+		if sem != nil {
+			<-sem
+		}
+	}
+}
+
+// GetSendConcurrency get count of in-flight and queued sending txs
+func GetSendConcurrency() (inFlight, queued int64) {
+	return atomic.LoadInt64(&sendInFlight), atomic.LoadInt64(&sendQueued)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"time"
 
 	"github.com/anyswap/CrossChain-Bridge/common"
 	"github.com/anyswap/CrossChain-Bridge/tools"
@@ -32,12 +33,126 @@ type ChainConfig struct {
 	Confirmations *uint64
 	InitialHeight *uint64
 	EnableScan    bool
+
+	// require confirmations by elapsed seconds instead of block count
+	ConfirmationSeconds uint64 `json:",omitempty"`
+
+	// finality mode: Confirmations/Finalized/Checkpoint (default to Confirmations)
+	// Confirmations (PoW) use 'Confirmations' or 'ConfirmationSeconds',
+	// Finalized (PoS) use the 'finalized' block tag, Checkpoint call
+	// 'FinalityCheckpointMethod' which returns the latest checkpointed block number
+	FinalityMode             string `json:",omitempty"`
+	FinalityCheckpointMethod string `json:",omitempty"`
+
+	// expected average block time in seconds (default to 15), used to derive intervals and timeouts
+	AverageBlockTime uint64 `json:",omitempty"`
+
+	// decimals of native coin (default to 18)
+	NativeDecimals *uint8 `json:",omitempty"`
+
+	// resolve ens names (eg. 'alice.eth') of bind address if configed
+	EnsRegistry string `json:",omitempty"`
+
+	// max size of tx input data (unlimited if not configed)
+	MaxTxDataSize uint64 `json:",omitempty"`
+
+	// reject building tx whose gas limit exceeds the gas limit of the latest block
+	CheckBlockGasLimit bool `json:",omitempty"`
+
+	// Multicall3 contract address, used to aggregate contract calls
+	MulticallAddress string `json:",omitempty"`
+
+	// estimate L1 data fee of L2 tx when checking balance (eg. Optimism)
+	L1FeeEstimator string `json:",omitempty"`
+
+	// cache suggested gas price for this seconds (no cache if 0)
+	GasPriceCacheTTL uint64 `json:",omitempty"`
+
+	// SafeERC20 helper contract address, used by pairs with 'UseSafeTransfer'
+	SafeTransferHelper string `json:",omitempty"`
+
+	// batch transfer helper contract address, used by pairs with 'BatchWindow'
+	// (call 'batchTransfer(address token, address[] receivers, uint256[] amounts)')
+	BatchTransferHelper string `json:",omitempty"`
+
+	// use only nonces which satisfy 'nonce % NonceStride == NonceOffset',
+	// so that processes sharing one address with distinct offsets never collide
+	NonceStride uint64 `json:",omitempty"` // default to 1
+	NonceOffset uint64 `json:",omitempty"`
+
+	// expected chain id of gateway, checked at startup and periodically
+	ChainID string `json:",omitempty"`
+
+	// build EIP-1559 tx with tip derived from fee history reward percentile
+	EnableDynamicFeeTx   bool    `json:",omitempty"`
+	FeeHistoryPercentile float64 `json:",omitempty"` // default to 50
+	DefaultGasTipCap     uint64  `json:",omitempty"` // in wei, used if fee history is unavailable
+	BaseFeeMarginPercent uint64  `json:",omitempty"` // max fee per gas is at least current base fee plus this percentage, default to 13
+
+	// 'DefaultGasTipCap' in gwei (can not be configed together with the wei one)
+	DefaultGasTipCapGwei float64 `json:",omitempty"`
+
+	// bump gas price by the rising percentage of base fee over recent 'BaseFeeTrendBlocks' blocks
+	// if it is at least 'BaseFeeTrendThreshold' (default to 10), at most 'BaseFeeTrendMaxPercentage'
+	// (default to 100). disabled if 'BaseFeeTrendBlocks' is 0 (use flat percentage only)
+	BaseFeeTrendBlocks        uint64 `json:",omitempty"`
+	BaseFeeTrendThreshold     uint64 `json:",omitempty"`
+	BaseFeeTrendMaxPercentage uint64 `json:",omitempty"`
+
+	// congestion level by percentage of current base fee to recent average
+	CongestionMediumPercent uint64 `json:",omitempty"` // default to 125
+	CongestionHighPercent   uint64 `json:",omitempty"` // default to 200
+
+	// default slippage tolerance in percentage of pairs with 'SwapinMinAmountOutFuncHash'
+	DefaultSlippageTolerance float64 `json:",omitempty"`
 }
 
 // GatewayConfig struct
 type GatewayConfig struct {
-	APIAddress []string
-	Extras     *GatewayExtras
+	APIAddress      []string
+	WriteAPIAddress []string `json:",omitempty"`
+	DefaultBlockTag string   `json:",omitempty"` // latest/pending/finalized/safe
+	Extras          *GatewayExtras
+
+	// query account nonce from all APIAddress and use the max one
+	// (prevent reusing nonce if some load-balanced backends are lagging)
+	MaxNonceOfAllGateways bool `json:",omitempty"`
+}
+
+// GetWriteAPIAddress get api addresses used to send transactions
+// (default to APIAddress if WriteAPIAddress is not configed)
+func (c *GatewayConfig) GetWriteAPIAddress() []string {
+	if len(c.WriteAPIAddress) != 0 {
+		return c.WriteAPIAddress
+	}
+	return c.APIAddress
+}
+
+// CheckConfig check gateway config
+func (c *GatewayConfig) CheckConfig() error {
+	switch c.DefaultBlockTag {
+	case "", "latest", "pending", "finalized", "safe":
+	default:
+		return fmt.Errorf("wrong gateway 'DefaultBlockTag' '%v'", c.DefaultBlockTag)
+	}
+	return nil
+}
+
+// GetBlockTag get configed default block tag (use 'defaultTag' if not configed)
+func (c *GatewayConfig) GetBlockTag(defaultTag string) string {
+	if c.DefaultBlockTag != "" {
+		return c.DefaultBlockTag
+	}
+	return defaultTag
+}
+
+// GetNonceBlockTag get block tag used to query account nonce,
+// only 'latest' or 'pending' is allowed to prevent reusing nonce
+func (c *GatewayConfig) GetNonceBlockTag() string {
+	if c.DefaultBlockTag == "latest" {
+		return c.DefaultBlockTag
+	}
+	return "pending"
 }
 
 // GatewayExtras struct
@@ -82,12 +197,130 @@ type TokenConfig struct {
 
 	DefaultGasLimit uint64 `json:",omitempty"`
 
+	// allow building swap with zero swapped value (eg. for special admin operations)
+	AllowZeroValueSwap bool `json:",omitempty"`
+
+	// reject building swap whose value in USD (estimated by the registered price oracle)
+	// exceeds this limit (no limit if 0), or require manual review like big value swap
+	// (pass by 'bigvalue' admin method) if 'MaxSwapUSDManualReview' is true
+	MaxSwapUSD             float64 `json:",omitempty"`
+	MaxSwapUSDManualReview bool    `json:",omitempty"`
+
+	// reject building swap whose final swapped value is below this amount (whole unit),
+	// for tokens which revert on dust transfers (no check if 0)
+	MinTransferAmount float64 `json:",omitempty"`
+
+	// override 'DefaultGasLimit' by swap direction
+	SwapinGasLimit  uint64 `json:",omitempty"`
+	SwapoutGasLimit uint64 `json:",omitempty"`
+
+	// reject mixed case bind address with wrong EIP-55 checksum when building tx
+	StrictBindChecksum bool `json:",omitempty"`
+
+	// swapout erc20 by calling 'safeTransfer(token,to,amount)' of chain's 'SafeTransferHelper'
+	// to tolerate tokens without bool return value (dcrm address should approve the helper)
+	UseSafeTransfer bool `json:",omitempty"`
+
+	// swapout erc20 by calling ERC677 'transferAndCall(to,amount,data)' of token contract
+	// with 'TransferAndCallData' (hex string) as the data argument
+	UseTransferAndCall  bool   `json:",omitempty"`
+	TransferAndCallData string `json:",omitempty"`
+
+	// pooled dcrm accounts besides 'DcrmAddress' (public keys in the same order).
+	// swap tx is sent from the account with enough balance and the lowest pending nonce
+	DcrmAddressPool []string `json:",omitempty"`
+	DcrmPubkeyPool  []string `json:"-"`
+
+	// tag swap tx data (hex string) for compliance. for contract call,
+	// data is 'selector ++ DataPrefix ++ args ++ DataSuffix';
+	// for memo of native swapout, data is 'DataPrefix ++ memo ++ DataSuffix'
+	DataPrefix string `json:",omitempty"`
+	DataSuffix string `json:",omitempty"`
+	// append trace id (8 bytes) of swap to the end of swap tx data
+	TraceIDInCalldata bool `json:",omitempty"`
+
+	// keep at least this native coin balance (whole unit) of dcrm address untouched
+	MinReserveBalance float64 `json:",omitempty"`
+
+	// reserve 'gasLimit * gasPrice * ReserveGasFeeBlocks' in fee currency for swap tx
+	// instead of the flat default reserve gas fee (0.01 native coin)
+	ReserveGasFeeBlocks uint64 `json:",omitempty"`
+
+	// erc20 token in which gas is paid (native coin if not configed)
+	FeeToken string `json:",omitempty"`
+
+	// require more confirmations of source tx for larger swap value
+	ConfirmationTiers []*ConfirmationTier `json:",omitempty"`
+
+	// query swap contract whether swapin is completed before building swapin tx
+	CheckSwapinCompleted  bool   `json:",omitempty"`
+	SwapinExistedFuncHash string `json:",omitempty"` // default to selector of 'swapinExisted(bytes32)'
+
+	// selector of the token's blacklist view, eg. 'isBlacklisted(address)' or 'isBlackListed(address)'
+	BlacklistFuncHash string `json:",omitempty"`
+
+	// selector of the contract's paused view, eg. 'paused()' 0x5c975abb (no check if empty)
+	PausedFuncHash string `json:",omitempty"`
+
+	// refuse broadcasting swap tx after this seconds since building (no deadline if 0)
+	SwapTxLifetime uint64 `json:",omitempty"`
+	// selector of swapin func with a trailing 'uint256 deadline' param
+	// (eg. 'Swapin(bytes32,address,uint256,uint256)'), used if swap tx has deadline
+	SwapinDeadlineFuncHash string `json:",omitempty"`
+	// selector of swapin func with a trailing 'uint256 minAmountOut' param
+	// (eg. router based swapin), 'minAmountOut' is the swapped value minus
+	// slippage tolerance in percentage (default to 'DefaultSlippageTolerance' of chain)
+	SwapinMinAmountOutFuncHash string  `json:",omitempty"`
+	SlippageTolerance          float64 `json:",omitempty"`
+
+	// gas price strategy: Fixed/Suggested/Oracle (default to Suggested)
+	// Fixed use 'FixedGasPrice' (in wei), Oracle use median suggested price of all gateways
+	GasPriceStrategy string `json:",omitempty"`
+	FixedGasPrice    uint64 `json:",omitempty"`
+	FallbackGasPrice uint64 `json:",omitempty"` // in wei, used if fetching gas price failed (fail if 0)
+
+	// gas prices in gwei (less error-prone than wei, at most 10000 gwei).
+	// 'FixedGasPriceGwei' and 'FallbackGasPriceGwei' can not be configed together with the wei ones.
+	// the built gas price (or max fee per gas) is bounded by 'MinGasPriceGwei' and 'MaxGasPriceGwei'
+	FixedGasPriceGwei    float64 `json:",omitempty"`
+	FallbackGasPriceGwei float64 `json:",omitempty"`
+	MinGasPriceGwei      float64 `json:",omitempty"`
+	MaxGasPriceGwei      float64 `json:",omitempty"`
+
+	// tx type: Auto/Legacy/AccessList/DynamicFee/Blob (default to Auto)
+	// Auto follow 'EnableDynamicFeeTx' of chain config
+	// Blob build EIP-4844 tx with blobs from the registered 'BlobSidecarBuilder'
+	TxType             string `json:",omitempty"`
+	BlobSidecarBuilder string `json:",omitempty"`
+
+	// override 'PlusGasPricePercentage' by swap direction
+	SwapinGasPricePercentage  uint64 `json:",omitempty"`
+	SwapoutGasPricePercentage uint64 `json:",omitempty"`
+
+	// limit swap txs per minute (token bucket with burst)
+	SwapRateLimit       float64 `json:",omitempty"`
+	SwapRateBurst       uint64  `json:",omitempty"`
+	SwapRateWaitSeconds uint64  `json:",omitempty"`
+
+	// limit txs sent from the sender address in one block (unlimited if 0),
+	// excess txs are deferred to the next block
+	MaxTxsPerBlock uint64 `json:",omitempty"`
+
+	// collect swapouts within this seconds and build them in one batch tx
+	// (flush when 'MaxBatchSize' is reached, no batching if 0)
+	BatchWindow  uint64 `json:",omitempty"`
+	MaxBatchSize uint64 `json:",omitempty"` // default to 10
+
 	// use private key address instead
 	DcrmAddressKeyStore string `json:"-"`
 	DcrmAddressPassword string `json:"-"`
 	DcrmAddressKeyFile  string `json:"-"`
 	dcrmAddressPriKey   *ecdsa.PrivateKey
 
+	// sign with secp256k1 key held in remote KMS instead
+	KmsSignURL string `json:"-"`
+	KmsKeyID   string `json:"-"`
+
 	// calced value
 	maxSwap          *big.Int
 	minSwap          *big.Int
@@ -96,6 +329,12 @@ type TokenConfig struct {
 	bigValThreshhold *big.Int
 }
 
+// ConfirmationTier required confirmations of swap value not less than 'MinValue'
+type ConfirmationTier struct {
+	MinValue      float64 // whole unit
+	Confirmations uint64
+}
+
 // IsErc20 return if token is erc20
 func (c *TokenConfig) IsErc20() bool {
 	return strings.EqualFold(c.ID, "ERC20") || c.IsProxyErc20()
@@ -129,14 +368,61 @@ func (s SwapType) String() string {
 	}
 }
 
+// gas price strategy constants
+const (
+	GasPriceStrategyFixed     = "Fixed"
+	GasPriceStrategySuggested = "Suggested"
+	GasPriceStrategyOracle    = "Oracle"
+)
+
+// tx type constants
+const (
+	TxTypeAuto       = "Auto"
+	TxTypeLegacy     = "Legacy"
+	TxTypeAccessList = "AccessList"
+	TxTypeDynamicFee = "DynamicFee"
+	TxTypeBlob       = "Blob"
+)
+
+// finality mode constants
+const (
+	FinalityModeConfirmations = "Confirmations"
+	FinalityModeFinalized     = "Finalized"
+	FinalityModeCheckpoint    = "Checkpoint"
+)
+
+// CongestionLevel type
+type CongestionLevel uint32
+
+// CongestionLevel constants
+const (
+	CongestionLow    CongestionLevel = iota // 0
+	CongestionMedium                        // 1
+	CongestionHigh                          // 2
+)
+
+func (l CongestionLevel) String() string {
+	switch l {
+	case CongestionLow:
+		return "low"
+	case CongestionMedium:
+		return "medium"
+	case CongestionHigh:
+		return "high"
+	default:
+		return fmt.Sprintf("unknown congestion level %d", l)
+	}
+}
+
 // SwapTxType type
 type SwapTxType uint32
 
 // SwapTxType constants
 const (
-	SwapinTx     SwapTxType = iota // 0
-	SwapoutTx                      // 1
-	P2shSwapinTx                   // 2
+	SwapinTx        SwapTxType = iota // 0
+	SwapoutTx                         // 1
+	P2shSwapinTx                      // 2
+	RefundSwapoutTx                   // 3
 )
 
 func (s SwapTxType) String() string {
@@ -147,6 +433,8 @@ func (s SwapTxType) String() string {
 		return "swapouttx"
 	case P2shSwapinTx:
 		return "p2shswapintx"
+	case RefundSwapoutTx:
+		return "refundswapouttx"
 	default:
 		return fmt.Sprintf("unknown swaptx type %d", s)
 	}
@@ -174,6 +462,27 @@ type TxStatus struct {
 	BlockTime     uint64      `json:"block_time"`
 }
 
+// AddressTxRecord tx involving address (see AddressTxHistoryGetter)
+type AddressTxRecord struct {
+	TxHash      string   `json:"txhash"`
+	BlockNumber uint64   `json:"blocknumber"`
+	From        string   `json:"from"`
+	To          string   `json:"to"`
+	Token       string   `json:"token,omitempty"` // erc20 contract, empty for native coin
+	Value       *big.Int `json:"value"`
+}
+
+// SwapinResult verified result of swapin tx
+type SwapinResult struct {
+	TxHash        string   `json:"txhash"`
+	PairID        string   `json:"pairid"`
+	BlockHeight   uint64   `json:"block_height"`
+	BlockHash     string   `json:"block_hash"`
+	Confirmations uint64   `json:"confirmations"`
+	Account       string   `json:"account"`
+	Amount        *big.Int `json:"amount"`
+}
+
 // SwapInfo struct
 type SwapInfo struct {
 	PairID     string     `json:"pairid,omitempty"`
@@ -182,6 +491,17 @@ type SwapInfo struct {
 	TxType     SwapTxType `json:"txtype,omitempty"`
 	Bind       string     `json:"bind,omitempty"`
 	Identifier string     `json:"identifier,omitempty"`
+	TraceID    string     `json:"traceid,omitempty"`
+}
+
+// EnsureTraceID generate trace id if absent. it's derived from pairID, swapID and bind,
+// so the source deposit and destination settlement of one swap share the same trace id.
+func (s *SwapInfo) EnsureTraceID() string {
+	if s.TraceID == "" {
+		key := strings.ToLower(s.PairID + ":" + s.SwapID + ":" + s.Bind)
+		s.TraceID = common.ToHex(common.Keccak256Hash([]byte(key)).Bytes()[:traceIDLength])
+	}
+	return s.TraceID
 }
 
 // BuildTxArgs struct
@@ -194,16 +514,26 @@ type BuildTxArgs struct {
 	Memo        string     `json:"memo,omitempty"`
 	Input       *[]byte    `json:"input,omitempty"`
 	Extra       *AllExtras `json:"extra,omitempty"`
+	NativeValue *big.Int   `json:"nativeValue,omitempty"` // native value sent along with token swap tx
+	Deadline    time.Time  `json:"deadline,omitempty"`    // refuse broadcasting after it (no deadline if zero)
 }
 
 // GetExtraArgs get extra args
 func (args *BuildTxArgs) GetExtraArgs() *BuildTxArgs {
 	return &BuildTxArgs{
-		SwapInfo: args.SwapInfo,
-		Extra:    args.Extra,
+		SwapInfo:    args.SwapInfo,
+		From:        args.From,
+		Extra:       args.Extra,
+		NativeValue: args.NativeValue,
+		Deadline:    args.Deadline,
 	}
 }
 
+// IsExpired is deadline of tx passed
+func (args *BuildTxArgs) IsExpired() bool {
+	return !args.Deadline.IsZero() && time.Now().After(args.Deadline)
+}
+
 // GetTxNonce get tx nonce
 func (args *BuildTxArgs) GetTxNonce() uint64 {
 	if args.Extra != nil && args.Extra.EthExtra != nil && args.Extra.EthExtra.Nonce != nil {
@@ -220,9 +550,13 @@ type AllExtras struct {
 
 // EthExtraArgs struct
 type EthExtraArgs struct {
-	Gas      *uint64  `json:"gas,omitempty"`
-	GasPrice *big.Int `json:"gasPrice,omitempty"`
-	Nonce    *uint64  `json:"nonce,omitempty"`
+	Gas       *uint64  `json:"gas,omitempty"`
+	GasPrice  *big.Int `json:"gasPrice,omitempty"`
+	Nonce     *uint64  `json:"nonce,omitempty"`
+	GasTipCap *big.Int `json:"gasTipCap,omitempty"` // EIP-1559 max priority fee per gas
+	GasFeeCap *big.Int `json:"gasFeeCap,omitempty"` // EIP-1559 max fee per gas
+
+	BlobFeeCap *big.Int `json:"blobFeeCap,omitempty"` // EIP-4844 max fee per blob gas
 }
 
 // BtcOutPoint struct
@@ -246,6 +580,33 @@ type P2shAddressInfo struct {
 	RedeemScriptDisasm string
 }
 
+// default average block time if 'AverageBlockTime' is not configed
+const defaultAverageBlockTime = 15 * time.Second
+
+// GetAverageBlockTime get average block time of chain
+func (c *ChainConfig) GetAverageBlockTime() time.Duration {
+	if c.AverageBlockTime == 0 {
+		return defaultAverageBlockTime
+	}
+	return time.Duration(c.AverageBlockTime) * time.Second
+}
+
+// default decimals of native coin if 'NativeDecimals' is not configed
+const defaultNativeDecimals = 18
+
+// GetNativeDecimals get decimals of native coin of chain
+func (c *ChainConfig) GetNativeDecimals() uint8 {
+	if c.NativeDecimals == nil {
+		return defaultNativeDecimals
+	}
+	return *c.NativeDecimals
+}
+
+// EstimateConfirmationTime estimate time of waiting so many confirmations
+func (c *ChainConfig) EstimateConfirmationTime(confs uint64) time.Duration {
+	return time.Duration(confs) * c.GetAverageBlockTime()
+}
+
 // CheckConfig check chain config
 func (c *ChainConfig) CheckConfig() error {
 	if c.BlockChain == "" {
@@ -260,9 +621,103 @@ func (c *ChainConfig) CheckConfig() error {
 	if c.InitialHeight == nil {
 		return errors.New("token must config 'InitialHeight'")
 	}
+	if c.NonceOffset > 0 && c.NonceOffset >= c.NonceStride {
+		return errors.New("wrong 'NonceOffset' (must be less than 'NonceStride')")
+	}
+	if c.SafeTransferHelper != "" && !common.IsHexAddress(c.SafeTransferHelper) {
+		return fmt.Errorf("wrong 'SafeTransferHelper' %v", c.SafeTransferHelper)
+	}
+	if c.BatchTransferHelper != "" && !common.IsHexAddress(c.BatchTransferHelper) {
+		return fmt.Errorf("wrong 'BatchTransferHelper' %v", c.BatchTransferHelper)
+	}
+	if c.ChainID != "" {
+		if _, ok := new(big.Int).SetString(c.ChainID, 0); !ok {
+			return fmt.Errorf("wrong 'ChainID' %v", c.ChainID)
+		}
+	}
+	if c.FeeHistoryPercentile < 0 || c.FeeHistoryPercentile > 100 {
+		return errors.New("wrong 'FeeHistoryPercentile' (must be in range [0,100])")
+	}
+	if c.BaseFeeTrendBlocks > 1024 {
+		return errors.New("wrong 'BaseFeeTrendBlocks' (must be at most 1024)")
+	}
+	if err := checkGasPriceConfig("DefaultGasTipCap", c.DefaultGasTipCap, c.DefaultGasTipCapGwei); err != nil {
+		return err
+	}
+	if c.CongestionMediumPercent > 0 && c.CongestionHighPercent > 0 &&
+		c.CongestionMediumPercent >= c.CongestionHighPercent {
+		return errors.New("wrong 'CongestionMediumPercent' (must be less than 'CongestionHighPercent')")
+	}
+	if c.DefaultSlippageTolerance < 0 || c.DefaultSlippageTolerance >= 100 {
+		return errors.New("wrong 'DefaultSlippageTolerance' (must be in range [0,100))")
+	}
+	switch c.FinalityMode {
+	case "", FinalityModeConfirmations, FinalityModeFinalized:
+	case FinalityModeCheckpoint:
+		if c.FinalityCheckpointMethod == "" {
+			return errors.New("'FinalityMode' Checkpoint require 'FinalityCheckpointMethod'")
+		}
+	default:
+		return fmt.Errorf("unknown 'FinalityMode' '%v'", c.FinalityMode)
+	}
+	return nil
+}
+
+// GetPlusGasPricePercentage get plus gas price percentage of swap type
+func (c *TokenConfig) GetPlusGasPricePercentage(swapType SwapType) uint64 {
+	switch {
+	case swapType == SwapinType && c.SwapinGasPricePercentage > 0:
+		return c.SwapinGasPricePercentage
+	case swapType == SwapoutType && c.SwapoutGasPricePercentage > 0:
+		return c.SwapoutGasPricePercentage
+	default:
+		return c.PlusGasPricePercentage
+	}
+}
+
+// max total size of 'DataPrefix' and 'DataSuffix' in bytes
+const maxDataTagSize = 1024
+
+func (c *TokenConfig) checkDataTag() error {
+	for _, tag := range []string{c.DataPrefix, c.DataSuffix} {
+		if common.HasHexPrefix(tag) {
+			tag = tag[2:]
+		}
+		if !common.IsHex(tag) {
+			return fmt.Errorf("wrong token config, 'DataPrefix' or 'DataSuffix' '%v' is not hex string", tag)
+		}
+	}
+	if len(common.FromHex(c.DataPrefix))+len(common.FromHex(c.DataSuffix)) > maxDataTagSize {
+		return fmt.Errorf("wrong token config, total size of 'DataPrefix' and 'DataSuffix' exceeds %v bytes", maxDataTagSize)
+	}
 	return nil
 }
 
+const defaultMaxBatchSize = 10
+
+// trace id length in bytes
+const traceIDLength = 8
+
+// GetMaxBatchSize get max batch size (default to 10)
+func (c *TokenConfig) GetMaxBatchSize() int {
+	if c.MaxBatchSize == 0 {
+		return defaultMaxBatchSize
+	}
+	return int(c.MaxBatchSize)
+}
+
+// GetDefaultGasLimit get default gas limit by swap type (return 0 if not configed)
+func (c *TokenConfig) GetDefaultGasLimit(swapType SwapType) uint64 {
+	switch {
+	case swapType == SwapinType && c.SwapinGasLimit > 0:
+		return c.SwapinGasLimit
+	case swapType == SwapoutType && c.SwapoutGasLimit > 0:
+		return c.SwapoutGasLimit
+	default:
+		return c.DefaultGasLimit
+	}
+}
+
 // CheckConfig check token config
 //nolint:gocyclo // keep TokenConfig check as whole
 func (c *TokenConfig) CheckConfig(isSrc bool) error {
@@ -300,6 +755,95 @@ func (c *TokenConfig) CheckConfig(isSrc bool) error {
 	if c.PlusGasPricePercentage > maxPlusGasPricePercentage {
 		return errors.New("too large 'PlusGasPricePercentage' value")
 	}
+	if c.SwapinGasPricePercentage > maxPlusGasPricePercentage {
+		return errors.New("too large 'SwapinGasPricePercentage' value")
+	}
+	if c.SwapoutGasPricePercentage > maxPlusGasPricePercentage {
+		return errors.New("too large 'SwapoutGasPricePercentage' value")
+	}
+	if err := c.checkGasPriceConfig(); err != nil {
+		return fmt.Errorf("wrong token config, %v", err)
+	}
+	switch c.GasPriceStrategy {
+	case "", GasPriceStrategySuggested, GasPriceStrategyOracle:
+	case GasPriceStrategyFixed:
+		if c.GetFixedGasPrice() == nil {
+			return errors.New("wrong token config, 'GasPriceStrategy' Fixed require positive 'FixedGasPrice' or 'FixedGasPriceGwei'")
+		}
+	default:
+		return fmt.Errorf("wrong token config, unknown 'GasPriceStrategy' '%v'", c.GasPriceStrategy)
+	}
+	switch c.TxType {
+	case "", TxTypeAuto, TxTypeLegacy, TxTypeDynamicFee, TxTypeBlob:
+	case TxTypeAccessList:
+		return errors.New("wrong token config, 'TxType' AccessList is not supported yet")
+	default:
+		return fmt.Errorf("wrong token config, unknown 'TxType' '%v'", c.TxType)
+	}
+	if (c.TxType == TxTypeBlob) != (c.BlobSidecarBuilder != "") {
+		return errors.New("wrong token config, 'TxType' Blob and 'BlobSidecarBuilder' should be configed together")
+	}
+	if c.BatchWindow > 0 && c.MaxBatchSize == 1 {
+		return errors.New("wrong token config, 'MaxBatchSize' should be larger than 1 if 'BatchWindow' is configed")
+	}
+	if c.BatchWindow > 0 && (c.UseSafeTransfer || c.UseTransferAndCall) {
+		return errors.New("wrong token config, 'BatchWindow' can not be used with 'UseSafeTransfer' or 'UseTransferAndCall'")
+	}
+	if c.SwapRateLimit < 0 {
+		return errors.New("wrong token config, negative 'SwapRateLimit'")
+	}
+	if c.MinReserveBalance < 0 {
+		return errors.New("wrong token config, negative 'MinReserveBalance'")
+	}
+	if c.MaxSwapUSD < 0 {
+		return errors.New("wrong token config, negative 'MaxSwapUSD'")
+	}
+	if c.MinTransferAmount < 0 {
+		return errors.New("wrong token config, negative 'MinTransferAmount'")
+	}
+	if err := c.checkDataTag(); err != nil {
+		return err
+	}
+	if c.UseSafeTransfer && c.UseTransferAndCall {
+		return errors.New("wrong token config, 'UseSafeTransfer' and 'UseTransferAndCall' are exclusive")
+	}
+	if data := c.TransferAndCallData; data != "" && !common.IsHex(strings.TrimPrefix(data, "0x")) {
+		return fmt.Errorf("wrong token config, 'TransferAndCallData' '%v' is not hex string", data)
+	}
+	for _, tier := range c.ConfirmationTiers {
+		if tier == nil || tier.MinValue < 0 || tier.Confirmations == 0 {
+			return errors.New("wrong token config, 'ConfirmationTiers' require non-negative 'MinValue' and positive 'Confirmations'")
+		}
+	}
+	if c.FeeToken != "" && !common.IsHexAddress(c.FeeToken) {
+		return fmt.Errorf("wrong 'FeeToken' address %v", c.FeeToken)
+	}
+	if c.SwapinExistedFuncHash != "" && len(common.FromHex(c.SwapinExistedFuncHash)) != 4 {
+		return errors.New("wrong token config, 'SwapinExistedFuncHash' should be 4 bytes hex")
+	}
+	if c.BlacklistFuncHash != "" && len(common.FromHex(c.BlacklistFuncHash)) != 4 {
+		return errors.New("wrong token config, 'BlacklistFuncHash' should be 4 bytes hex")
+	}
+	if c.PausedFuncHash != "" && len(common.FromHex(c.PausedFuncHash)) != 4 {
+		return errors.New("wrong token config, 'PausedFuncHash' should be 4 bytes hex")
+	}
+	if c.SwapinDeadlineFuncHash != "" && len(common.FromHex(c.SwapinDeadlineFuncHash)) != 4 {
+		return errors.New("wrong token config, 'SwapinDeadlineFuncHash' should be 4 bytes hex")
+	}
+	if c.SwapinMinAmountOutFuncHash != "" {
+		if len(common.FromHex(c.SwapinMinAmountOutFuncHash)) != 4 {
+			return errors.New("wrong token config, 'SwapinMinAmountOutFuncHash' should be 4 bytes hex")
+		}
+		if c.SwapinDeadlineFuncHash != "" {
+			return errors.New("wrong token config, 'SwapinMinAmountOutFuncHash' and 'SwapinDeadlineFuncHash' are exclusive")
+		}
+	}
+	if c.SlippageTolerance < 0 || c.SlippageTolerance >= 100 {
+		return errors.New("wrong token config, 'SlippageTolerance' should be in range [0,100)")
+	}
+	if c.KmsKeyID != "" && c.KmsSignURL == "" {
+		return errors.New("token must config 'KmsSignURL' if 'KmsKeyID' is configed")
+	}
 	if c.BigValueThreshold == nil {
 		return errors.New("token must config 'BigValueThreshold'")
 	}
@@ -318,6 +862,12 @@ func (c *TokenConfig) CheckConfig(isSrc bool) error {
 	if isSrc && c.IsProxyErc20() && c.ContractCodeHash == "" {
 		return errors.New("token must config 'ContractCodeHash' for ProxyERC20 in source chain")
 	}
+	if len(c.DcrmAddressPool) != len(c.DcrmPubkeyPool) {
+		return errors.New("wrong token config, 'DcrmAddressPool' and 'DcrmPubkeyPool' length mismatch")
+	}
+	if len(c.DcrmAddressPool) > 0 && (c.IsKmsSign() || c.DcrmAddressKeyFile != "" || c.DcrmAddressKeyStore != "") {
+		return errors.New("wrong token config, 'DcrmAddressPool' only support dcrm signing")
+	}
 	// calc value and store
 	c.CalcAndStoreValue()
 	err := c.LoadDcrmAddressPrivateKey()
@@ -327,6 +877,31 @@ func (c *TokenConfig) CheckConfig(isSrc bool) error {
 	return c.VerifyDcrmPublicKey()
 }
 
+// GetDcrmAccounts get 'DcrmAddress' and pooled dcrm addresses
+func (c *TokenConfig) GetDcrmAccounts() []string {
+	return append([]string{c.DcrmAddress}, c.DcrmAddressPool...)
+}
+
+// IsDcrmAccount is 'DcrmAddress' or pooled dcrm address
+func (c *TokenConfig) IsDcrmAccount(address string) bool {
+	for _, account := range c.GetDcrmAccounts() {
+		if strings.EqualFold(account, address) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetDcrmPubkeyOf get dcrm public key of 'DcrmAddress' or pooled dcrm address
+func (c *TokenConfig) GetDcrmPubkeyOf(address string) string {
+	for i, account := range c.DcrmAddressPool {
+		if strings.EqualFold(account, address) {
+			return c.DcrmPubkeyPool[i]
+		}
+	}
+	return c.DcrmPubkey
+}
+
 // CalcAndStoreValue calc and store value (minus duplicate calculation)
 func (c *TokenConfig) CalcAndStoreValue() {
 	c.maxSwap = ToBits(*c.MaximumSwap, *c.Decimals)
@@ -336,6 +911,11 @@ func (c *TokenConfig) CalcAndStoreValue() {
 	c.bigValThreshhold = ToBits(*c.BigValueThreshold, *c.Decimals)
 }
 
+// IsKmsSign is sign with remote KMS
+func (c *TokenConfig) IsKmsSign() bool {
+	return c.KmsKeyID != ""
+}
+
 // GetDcrmAddressPrivateKey get private key
 func (c *TokenConfig) GetDcrmAddressPrivateKey() *ecdsa.PrivateKey {
 	return c.dcrmAddressPriKey
@@ -377,11 +957,20 @@ func (c *TokenConfig) VerifyDcrmPublicKey() error {
 	if !common.IsHexAddress(c.DcrmAddress) {
 		return nil
 	}
+	for i, address := range c.DcrmAddressPool {
+		if err := verifyEthDcrmPublicKey(address, c.DcrmPubkeyPool[i]); err != nil {
+			return err
+		}
+	}
 	if c.dcrmAddressPriKey != nil && c.DcrmPubkey == "" {
 		return nil
 	}
+	return verifyEthDcrmPublicKey(c.DcrmAddress, c.DcrmPubkey)
+}
+
+func verifyEthDcrmPublicKey(address, pubkey string) error {
 	// ETH like address
-	pkBytes := common.FromHex(c.DcrmPubkey)
+	pkBytes := common.FromHex(pubkey)
 	if len(pkBytes) != 65 || pkBytes[0] != 4 {
 		return fmt.Errorf("wrong dcrm public key, shoule be uncompressed")
 	}
@@ -391,8 +980,8 @@ func (c *TokenConfig) VerifyDcrmPublicKey() error {
 		Y:     new(big.Int).SetBytes(pkBytes[33:65]),
 	}
 	pubAddr := crypto.PubkeyToAddress(pubKey)
-	if !strings.EqualFold(pubAddr.String(), c.DcrmAddress) {
-		return fmt.Errorf("dcrm address %v and public key address %v is not match", c.DcrmAddress, pubAddr.String())
+	if !strings.EqualFold(pubAddr.String(), address) {
+		return fmt.Errorf("dcrm address %v and public key address %v is not match", address, pubAddr.String())
 	}
 	return nil
 }
@@ -0,0 +1,55 @@
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+)
+
+// personalMessagePrefix EIP-191 version 0x45 (personal_sign) prefix
+const personalMessagePrefix = "\x19Ethereum Signed Message:\n"
+
+// PersonalMessageHash get EIP-191 digest of personal message
+// keccak256("\x19Ethereum Signed Message:\n" + len(msg) + msg)
+func PersonalMessageHash(msg []byte) []byte {
+	prefix := fmt.Sprintf("%s%d", personalMessagePrefix, len(msg))
+	return Keccak256([]byte(prefix), msg)
+}
+
+// PersonalSign sign personal message, V of returned signature is 27 or 28 (the same as wallets)
+func PersonalSign(msg []byte, prv *ecdsa.PrivateKey) ([]byte, error) {
+	sig, err := Sign(PersonalMessageHash(msg), prv)
+	if err != nil {
+		return nil, err
+	}
+	sig[RecoveryIDOffset] += 27
+	return sig, nil
+}
+
+// RecoverPersonalSigner recover signer address of personal message signature (V can be 0/1 or 27/28)
+func RecoverPersonalSigner(msg, sig []byte) (common.Address, error) {
+	if len(sig) != SignatureLength {
+		return common.Address{}, fmt.Errorf("wrong signature length %v", len(sig))
+	}
+	rsv := make([]byte, SignatureLength)
+	copy(rsv, sig)
+	if rsv[RecoveryIDOffset] >= 27 {
+		rsv[RecoveryIDOffset] -= 27
+	}
+	if rsv[RecoveryIDOffset] > 1 {
+		return common.Address{}, errors.New("wrong signature recovery id")
+	}
+	pubkey, err := SigToPub(PersonalMessageHash(msg), rsv)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return PubkeyToAddress(*pubkey), nil
+}
+
+// VerifyPersonalSignature verify personal message is signed by address
+func VerifyPersonalSignature(address common.Address, msg, sig []byte) bool {
+	signer, err := RecoverPersonalSigner(msg, sig)
+	return err == nil && signer == address
+}
@@ -0,0 +1,62 @@
+package crypto
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/common/hexutil"
+)
+
+var (
+	testPersonalKey  = "0123456789012345678901234567890123456789012345678901234567890123"
+	testPersonalAddr = common.HexToAddress("0x14791697260E4c9A71f18484C9f997B308e59325")
+	testPersonalMsg  = []byte("Hello World")
+	// deterministic (RFC 6979) signature of testPersonalMsg by testPersonalKey
+	testPersonalSig = hexutil.MustDecode("0xe0ed34fbbe927a58267ce2e8067a611c69869e20e731bc99187a8bc97058664c16de07f7660f06ce0985d1d8e063726783033fda59b307897f26a21392d62b3a1c")
+)
+
+func TestPersonalMessageHash(t *testing.T) {
+	want := hexutil.MustDecode("0xd9eba16ed0ecae432b71fe008c98cc872bb4cc214d3220a36f365326cf807d68")
+	if got := PersonalMessageHash([]byte("hello world")); !bytes.Equal(got, want) {
+		t.Errorf("personal message hash mismatch: want %x, got %x", want, got)
+	}
+}
+
+func TestPersonalSign(t *testing.T) {
+	key, err := HexToECDSA(testPersonalKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := PersonalSign(testPersonalMsg, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(sig, testPersonalSig) {
+		t.Errorf("personal sign mismatch: want %x, got %x", testPersonalSig, sig)
+	}
+}
+
+func TestRecoverPersonalSigner(t *testing.T) {
+	signer, err := RecoverPersonalSigner(testPersonalMsg, testPersonalSig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signer != testPersonalAddr {
+		t.Errorf("recover signer mismatch: want %v, got %v", testPersonalAddr.String(), signer.String())
+	}
+
+	// recovery id 0/1 is also accepted
+	sig := common.CopyBytes(testPersonalSig)
+	sig[RecoveryIDOffset] -= 27
+	if !VerifyPersonalSignature(testPersonalAddr, testPersonalMsg, sig) {
+		t.Errorf("verify signature with recovery id 0/1 failed")
+	}
+
+	if VerifyPersonalSignature(testPersonalAddr, []byte("Hello World!"), testPersonalSig) {
+		t.Errorf("verify signature of tampered message should fail")
+	}
+	if _, err = RecoverPersonalSigner(testPersonalMsg, testPersonalSig[:64]); err == nil {
+		t.Errorf("recover signer with short signature should fail")
+	}
+}
@@ -0,0 +1,217 @@
+package types
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/tools/rlp"
+)
+
+// EIP-4844 blob constants
+const (
+	BlobSize                 = 131072 // 4096 field elements of 32 bytes
+	BlobCommitmentSize       = 48
+	BlobProofSize            = 48
+	BlobGasPerBlob           = 1 << 17
+	BlobCommitmentVersionKZG = 0x01
+)
+
+// BlobTxSidecar blobs and their kzg commitments and proofs of blob tx,
+// which are not part of tx hash but required when sending the tx
+type BlobTxSidecar struct {
+	Blobs       [][]byte
+	Commitments [][]byte
+	Proofs      [][]byte
+}
+
+// Validate check count and sizes of blobs, commitments and proofs
+func (sc *BlobTxSidecar) Validate() error {
+	if len(sc.Blobs) == 0 {
+		return errors.New("blob tx sidecar without blobs")
+	}
+	if len(sc.Commitments) != len(sc.Blobs) || len(sc.Proofs) != len(sc.Blobs) {
+		return fmt.Errorf("blob tx sidecar count mismatch: blobs %v, commitments %v, proofs %v",
+			len(sc.Blobs), len(sc.Commitments), len(sc.Proofs))
+	}
+	for i := range sc.Blobs {
+		if len(sc.Blobs[i]) != BlobSize {
+			return fmt.Errorf("blob %v has wrong size %v", i, len(sc.Blobs[i]))
+		}
+		if len(sc.Commitments[i]) != BlobCommitmentSize {
+			return fmt.Errorf("blob commitment %v has wrong size %v", i, len(sc.Commitments[i]))
+		}
+		if len(sc.Proofs[i]) != BlobProofSize {
+			return fmt.Errorf("blob proof %v has wrong size %v", i, len(sc.Proofs[i]))
+		}
+	}
+	return nil
+}
+
+// BlobHashes versioned hashes of commitments, 'version || sha256(commitment)[1:]'
+func (sc *BlobTxSidecar) BlobHashes() []common.Hash {
+	hashes := make([]common.Hash, len(sc.Commitments))
+	for i, commitment := range sc.Commitments {
+		hashes[i] = sha256.Sum256(commitment)
+		hashes[i][0] = BlobCommitmentVersionKZG
+	}
+	return hashes
+}
+
+// blobTxRLP is the rlp encoding payload of EIP-4844 tx
+type blobTxRLP struct {
+	ChainID    *big.Int
+	Nonce      uint64
+	GasTipCap  *big.Int
+	GasFeeCap  *big.Int
+	Gas        uint64
+	To         common.Address
+	Value      *big.Int
+	Data       []byte
+	AccessList []accessTuple
+	BlobFeeCap *big.Int
+	BlobHashes []common.Hash
+	V, R, S    *big.Int
+}
+
+// blobTxNetworkRLP is the network encoding payload of EIP-4844 tx with sidecar
+type blobTxNetworkRLP struct {
+	Tx          *blobTxRLP
+	Blobs       [][]byte
+	Commitments [][]byte
+	Proofs      [][]byte
+}
+
+// NewBlobTx new EIP-4844 tx (blob tx can not create contract)
+func NewBlobTx(chainID *big.Int, nonce uint64, to common.Address, amount *big.Int, gasLimit uint64, gasTipCap, gasFeeCap, blobFeeCap *big.Int, data []byte, sidecar *BlobTxSidecar) *Transaction {
+	tx := NewDynamicFeeTx(chainID, nonce, &to, amount, gasLimit, gasTipCap, gasFeeCap, data)
+	tx.data.Type = BlobTxType
+	tx.data.BlobFeeCap = new(big.Int)
+	if blobFeeCap != nil {
+		tx.data.BlobFeeCap.Set(blobFeeCap)
+	}
+	if sidecar != nil {
+		tx.data.BlobHashes = sidecar.BlobHashes()
+		tx.data.Sidecar = sidecar
+	}
+	return tx
+}
+
+// BlobGas blob gas used by blob tx
+func (tx *Transaction) BlobGas() uint64 {
+	return uint64(len(tx.data.BlobHashes)) * BlobGasPerBlob
+}
+
+// BlobGasFeeCap max fee per blob gas of blob tx (nil for other tx types)
+func (tx *Transaction) BlobGasFeeCap() *big.Int {
+	if tx.data.BlobFeeCap == nil {
+		return nil
+	}
+	return new(big.Int).Set(tx.data.BlobFeeCap)
+}
+
+// BlobHashes versioned hashes of blobs
+func (tx *Transaction) BlobHashes() []common.Hash {
+	return append([]common.Hash(nil), tx.data.BlobHashes...)
+}
+
+// BlobTxSidecar sidecar of blob tx
+func (tx *Transaction) BlobTxSidecar() *BlobTxSidecar { return tx.data.Sidecar }
+
+func (tx *Transaction) toBlobTxRLP() *blobTxRLP {
+	var to common.Address
+	if tx.data.Recipient != nil {
+		to = *tx.data.Recipient
+	}
+	return &blobTxRLP{
+		ChainID:    tx.data.ChainID,
+		Nonce:      tx.data.AccountNonce,
+		GasTipCap:  tx.data.GasTipCap,
+		GasFeeCap:  tx.data.Price,
+		Gas:        tx.data.GasLimit,
+		To:         to,
+		Value:      tx.data.Amount,
+		Data:       tx.data.Payload,
+		AccessList: []accessTuple{},
+		BlobFeeCap: tx.data.BlobFeeCap,
+		BlobHashes: tx.data.BlobHashes,
+		V:          tx.data.V,
+		R:          tx.data.R,
+		S:          tx.data.S,
+	}
+}
+
+// encodeBlobTx use network encoding if it has sidecar
+func (tx *Transaction) encodeBlobTx() ([]byte, error) {
+	var payload []byte
+	var err error
+	if sc := tx.data.Sidecar; sc != nil {
+		payload, err = rlp.EncodeToBytes(&blobTxNetworkRLP{
+			Tx:          tx.toBlobTxRLP(),
+			Blobs:       sc.Blobs,
+			Commitments: sc.Commitments,
+			Proofs:      sc.Proofs,
+		})
+	} else {
+		payload, err = rlp.EncodeToBytes(tx.toBlobTxRLP())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{BlobTxType}, payload...), nil
+}
+
+// decodeBlobTx decode canonical or network encoding (without type prefix)
+func (tx *Transaction) decodeBlobTx(b []byte) error {
+	_, content, _, err := rlp.Split(b)
+	if err != nil {
+		return err
+	}
+	kind, _, _, err := rlp.Split(content)
+	if err != nil {
+		return err
+	}
+	var inner *blobTxRLP
+	var sidecar *BlobTxSidecar
+	if kind == rlp.List {
+		var network blobTxNetworkRLP
+		if err = rlp.DecodeBytes(b, &network); err != nil {
+			return err
+		}
+		inner = network.Tx
+		sidecar = &BlobTxSidecar{
+			Blobs:       network.Blobs,
+			Commitments: network.Commitments,
+			Proofs:      network.Proofs,
+		}
+	} else {
+		inner = new(blobTxRLP)
+		if err = rlp.DecodeBytes(b, inner); err != nil {
+			return err
+		}
+	}
+	if len(inner.AccessList) != 0 {
+		return ErrTxTypeNotSupported
+	}
+	to := inner.To
+	tx.data = txdata{
+		AccountNonce: inner.Nonce,
+		Price:        inner.GasFeeCap,
+		GasLimit:     inner.Gas,
+		Recipient:    &to,
+		Amount:       inner.Value,
+		Payload:      inner.Data,
+		V:            inner.V,
+		R:            inner.R,
+		S:            inner.S,
+		Type:         BlobTxType,
+		ChainID:      inner.ChainID,
+		GasTipCap:    inner.GasTipCap,
+		BlobFeeCap:   inner.BlobFeeCap,
+		BlobHashes:   inner.BlobHashes,
+		Sidecar:      sidecar,
+	}
+	return nil
+}
@@ -8,7 +8,6 @@ import (
 
 	"github.com/anyswap/CrossChain-Bridge/common"
 	"github.com/anyswap/CrossChain-Bridge/common/hexutil"
-	"github.com/anyswap/CrossChain-Bridge/tools/rlp"
 )
 
 // MarshalJSON marshals as JSON.
@@ -24,6 +23,12 @@ func (t *txdata) MarshalJSON() ([]byte, error) {
 		R            *hexutil.Big    `json:"r" gencodec:"required"`
 		S            *hexutil.Big    `json:"s" gencodec:"required"`
 		Hash         *common.Hash    `json:"hash" rlp:"-"`
+		Type         *hexutil.Uint64 `json:"type,omitempty"`
+		ChainID      *hexutil.Big    `json:"chainId,omitempty"`
+		GasTipCap    *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty"`
+		GasFeeCap    *hexutil.Big    `json:"maxFeePerGas,omitempty"`
+		BlobFeeCap   *hexutil.Big    `json:"maxFeePerBlobGas,omitempty"`
+		BlobHashes   []common.Hash   `json:"blobVersionedHashes,omitempty"`
 	}
 	var enc txdata
 	enc.AccountNonce = hexutil.Uint64(t.AccountNonce)
@@ -36,6 +41,17 @@ func (t *txdata) MarshalJSON() ([]byte, error) {
 	enc.R = (*hexutil.Big)(t.R)
 	enc.S = (*hexutil.Big)(t.S)
 	enc.Hash = t.Hash
+	if t.Type != LegacyTxType {
+		txType := hexutil.Uint64(t.Type)
+		enc.Type = &txType
+		enc.ChainID = (*hexutil.Big)(t.ChainID)
+		enc.GasTipCap = (*hexutil.Big)(t.GasTipCap)
+		enc.GasFeeCap = (*hexutil.Big)(t.Price)
+	}
+	if t.Type == BlobTxType {
+		enc.BlobFeeCap = (*hexutil.Big)(t.BlobFeeCap)
+		enc.BlobHashes = t.BlobHashes
+	}
 	return json.Marshal(&enc)
 }
 
@@ -52,6 +68,12 @@ func (t *txdata) UnmarshalJSON(input []byte) error {
 		R            *hexutil.Big    `json:"r" gencodec:"required"`
 		S            *hexutil.Big    `json:"s" gencodec:"required"`
 		Hash         *common.Hash    `json:"hash" rlp:"-"`
+		Type         *hexutil.Uint64 `json:"type,omitempty"`
+		ChainID      *hexutil.Big    `json:"chainId,omitempty"`
+		GasTipCap    *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty"`
+		GasFeeCap    *hexutil.Big    `json:"maxFeePerGas,omitempty"`
+		BlobFeeCap   *hexutil.Big    `json:"maxFeePerBlobGas,omitempty"`
+		BlobHashes   []common.Hash   `json:"blobVersionedHashes,omitempty"`
 	}
 	var dec txdata
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -61,6 +83,31 @@ func (t *txdata) UnmarshalJSON(input []byte) error {
 		return errors.New("missing required field 'nonce' for txdata")
 	}
 	t.AccountNonce = uint64(*dec.AccountNonce)
+	if dec.Type != nil && *dec.Type != LegacyTxType {
+		if *dec.Type != DynamicFeeTxType && *dec.Type != BlobTxType {
+			return ErrTxTypeNotSupported
+		}
+		if *dec.Type == BlobTxType {
+			if dec.BlobFeeCap == nil {
+				return errors.New("missing required field 'maxFeePerBlobGas' for txdata")
+			}
+			t.BlobFeeCap = (*big.Int)(dec.BlobFeeCap)
+			t.BlobHashes = dec.BlobHashes
+		}
+		if dec.ChainID == nil {
+			return errors.New("missing required field 'chainId' for txdata")
+		}
+		if dec.GasTipCap == nil {
+			return errors.New("missing required field 'maxPriorityFeePerGas' for txdata")
+		}
+		if dec.GasFeeCap == nil {
+			return errors.New("missing required field 'maxFeePerGas' for txdata")
+		}
+		t.Type = uint8(*dec.Type)
+		t.ChainID = (*big.Int)(dec.ChainID)
+		t.GasTipCap = (*big.Int)(dec.GasTipCap)
+		dec.Price = dec.GasFeeCap
+	}
 	if dec.Price == nil {
 		return errors.New("missing required field 'gasPrice' for txdata")
 	}
@@ -106,12 +153,12 @@ func (tx *Transaction) PrintPretty() {
 
 // PrintRaw print raw encoded (hex string)
 func (tx *Transaction) PrintRaw() {
-	bs, _ := rlp.EncodeToBytes(tx)
+	bs, _ := tx.MarshalBinary()
 	fmt.Println(hexutil.Bytes(bs))
 }
 
 // RawStr return raw encoded (hex string)
 func (tx *Transaction) RawStr() string {
-	bs, _ := rlp.EncodeToBytes(tx)
+	bs, _ := tx.MarshalBinary()
 	return string(bs)
 }
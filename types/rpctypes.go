@@ -30,6 +30,7 @@ type RPCBlock struct {
 	TotalDifficulty *hexutil.Big    `json:"totalDifficulty"`
 	Transactions    []*common.Hash  `json:"transactions"`
 	Uncles          []*common.Hash  `json:"uncles"`
+	BaseFee         *hexutil.Big    `json:"baseFeePerGas,omitempty"`
 }
 
 // RPCTransaction struct
@@ -41,6 +42,8 @@ type RPCTransaction struct {
 	From             *common.Address `json:"from,omitempty"`
 	AccountNonce     *hexutil.Uint64 `json:"nonce"`
 	Price            *hexutil.Big    `json:"gasPrice"`
+	GasFeeCap        *hexutil.Big    `json:"maxFeePerGas,omitempty"`
+	GasTipCap        *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty"`
 	GasLimit         *hexutil.Uint64 `json:"gas"`
 	Recipient        *common.Address `json:"to"`
 	Amount           *hexutil.Big    `json:"value"`
@@ -50,6 +53,14 @@ type RPCTransaction struct {
 	S                *hexutil.Big    `json:"s"`
 }
 
+// RPCFeeHistory struct
+type RPCFeeHistory struct {
+	OldestBlock  *hexutil.Big     `json:"oldestBlock"`
+	Reward       [][]*hexutil.Big `json:"reward,omitempty"`
+	BaseFee      []*hexutil.Big   `json:"baseFeePerGas,omitempty"`
+	GasUsedRatio []float64        `json:"gasUsedRatio"`
+}
+
 // RPCLog struct
 type RPCLog struct {
 	Address     *common.Address `json:"address"`
@@ -65,6 +76,7 @@ type RPCLog struct {
 
 // RPCTxReceipt struct
 type RPCTxReceipt struct {
+	Type              *hexutil.Uint64 `json:"type,omitempty"`
 	TxHash            *common.Hash    `json:"transactionHash"`
 	TxIndex           *hexutil.Uint   `json:"transactionIndex"`
 	BlockNumber       *hexutil.Big    `json:"blockNumber"`
@@ -74,6 +86,7 @@ type RPCTxReceipt struct {
 	From              *common.Address `json:"from"`
 	Recipient         *common.Address `json:"to"`
 	GasUsed           *hexutil.Uint64 `json:"gasUsed"`
+	EffectiveGasPrice *hexutil.Big    `json:"effectiveGasPrice,omitempty"`
 	CumulativeGasUsed *hexutil.Uint64 `json:"cumulativeGasUsed"`
 	ContractAddress   *common.Address `json:"contractAddress,omitempty"`
 	Bloom             *hexutil.Bytes  `json:"logsBloom"`
@@ -11,6 +11,13 @@ import (
 	"golang.org/x/crypto/sha3"
 )
 
+// transaction types
+const (
+	LegacyTxType     = 0x00
+	DynamicFeeTxType = 0x02
+	BlobTxType       = 0x03
+)
+
 // StorageSize type
 type StorageSize float64
 
@@ -38,6 +45,37 @@ type txdata struct {
 
 	// This is only used when marshaling to JSON.
 	Hash *common.Hash `json:"hash" rlp:"-"`
+
+	// Typed transaction values, not included in legacy rlp encoding.
+	// For dynamic fee tx, 'Price' is the max fee per gas.
+	Type      uint8    `json:"type"                 rlp:"-"`
+	ChainID   *big.Int `json:"chainId"              rlp:"-"`
+	GasTipCap *big.Int `json:"maxPriorityFeePerGas" rlp:"-"`
+
+	// Blob tx values. sidecar is only included in network encoding.
+	BlobFeeCap *big.Int       `json:"maxFeePerBlobGas"    rlp:"-"`
+	BlobHashes []common.Hash  `json:"blobVersionedHashes" rlp:"-"`
+	Sidecar    *BlobTxSidecar `json:"-"                   rlp:"-"`
+}
+
+// dynamicFeeTxRLP is the rlp encoding payload of EIP-1559 tx
+type dynamicFeeTxRLP struct {
+	ChainID    *big.Int
+	Nonce      uint64
+	GasTipCap  *big.Int
+	GasFeeCap  *big.Int
+	Gas        uint64
+	To         *common.Address `rlp:"nil"`
+	Value      *big.Int
+	Data       []byte
+	AccessList []accessTuple
+	V, R, S    *big.Int
+}
+
+// accessTuple is the element type of an access list
+type accessTuple struct {
+	Address     common.Address
+	StorageKeys []common.Hash
 }
 
 // NewTransaction new tx
@@ -75,13 +113,37 @@ func newTransaction(nonce uint64, to *common.Address, amount *big.Int, gasLimit
 	return &Transaction{data: d}
 }
 
+// NewDynamicFeeTx new EIP-1559 tx
+func NewDynamicFeeTx(chainID *big.Int, nonce uint64, to *common.Address, amount *big.Int, gasLimit uint64, gasTipCap, gasFeeCap *big.Int, data []byte) *Transaction {
+	tx := newTransaction(nonce, to, amount, gasLimit, gasFeeCap, data)
+	tx.data.Type = DynamicFeeTxType
+	tx.data.ChainID = new(big.Int)
+	if chainID != nil {
+		tx.data.ChainID.Set(chainID)
+	}
+	tx.data.GasTipCap = new(big.Int)
+	if gasTipCap != nil {
+		tx.data.GasTipCap.Set(gasTipCap)
+	}
+	return tx
+}
+
+// Type returns the transaction type
+func (tx *Transaction) Type() uint8 { return tx.data.Type }
+
 // ChainID returns which chain id this transaction was signed for (if at all)
 func (tx *Transaction) ChainID() *big.Int {
+	if tx.data.Type != LegacyTxType {
+		return new(big.Int).Set(tx.data.ChainID)
+	}
 	return deriveChainID(tx.data.V)
 }
 
 // Protected returns whether the transaction is protected from replay protection.
 func (tx *Transaction) Protected() bool {
+	if tx.data.Type != LegacyTxType {
+		return true
+	}
 	return isProtectedV(tx.data.V)
 }
 
@@ -95,13 +157,32 @@ func isProtectedV(rsvV *big.Int) bool {
 }
 
 // EncodeRLP implements rlp.Encoder
+// typed tx is encoded as rlp string of its binary encoding
 func (tx *Transaction) EncodeRLP(w io.Writer) error {
-	return rlp.Encode(w, &tx.data)
+	if tx.data.Type == LegacyTxType {
+		return rlp.Encode(w, &tx.data)
+	}
+	enc, err := tx.encodeTyped()
+	if err != nil {
+		return err
+	}
+	return rlp.Encode(w, enc)
 }
 
 // DecodeRLP implements rlp.Decoder
 func (tx *Transaction) DecodeRLP(s *rlp.Stream) error {
-	_, size, _ := s.Kind()
+	kind, size, _ := s.Kind()
+	if kind != rlp.List {
+		enc, err := s.Bytes()
+		if err != nil {
+			return err
+		}
+		err = tx.decodeTyped(enc)
+		if err == nil {
+			tx.size.Store(StorageSize(len(enc)))
+		}
+		return err
+	}
 	err := s.Decode(&tx.data)
 	if err == nil {
 		tx.size.Store(StorageSize(rlp.ListSize(size)))
@@ -110,6 +191,101 @@ func (tx *Transaction) DecodeRLP(s *rlp.Stream) error {
 	return err
 }
 
+// MarshalBinary returns the canonical encoding of the transaction,
+// which is the rlp encoding for legacy tx and 'type || rlp(payload)' for typed tx.
+// Use it when sending raw transaction to the node.
+func (tx *Transaction) MarshalBinary() ([]byte, error) {
+	if tx.data.Type == LegacyTxType {
+		return rlp.EncodeToBytes(&tx.data)
+	}
+	return tx.encodeTyped()
+}
+
+// UnmarshalBinary decodes the canonical encoding of transaction
+func (tx *Transaction) UnmarshalBinary(b []byte) error {
+	if len(b) > 0 && b[0] > 0x7f {
+		var data txdata
+		if err := rlp.DecodeBytes(b, &data); err != nil {
+			return err
+		}
+		*tx = Transaction{data: data}
+		tx.size.Store(StorageSize(len(b)))
+		return nil
+	}
+	var dec Transaction
+	if err := dec.decodeTyped(b); err != nil {
+		return err
+	}
+	*tx = Transaction{data: dec.data}
+	tx.size.Store(StorageSize(len(b)))
+	return nil
+}
+
+func (tx *Transaction) toDynamicFeeTxRLP() *dynamicFeeTxRLP {
+	return &dynamicFeeTxRLP{
+		ChainID:    tx.data.ChainID,
+		Nonce:      tx.data.AccountNonce,
+		GasTipCap:  tx.data.GasTipCap,
+		GasFeeCap:  tx.data.Price,
+		Gas:        tx.data.GasLimit,
+		To:         tx.data.Recipient,
+		Value:      tx.data.Amount,
+		Data:       tx.data.Payload,
+		AccessList: []accessTuple{},
+		V:          tx.data.V,
+		R:          tx.data.R,
+		S:          tx.data.S,
+	}
+}
+
+func (tx *Transaction) encodeTyped() ([]byte, error) {
+	if tx.data.Type == BlobTxType {
+		return tx.encodeBlobTx()
+	}
+	if tx.data.Type != DynamicFeeTxType {
+		return nil, ErrTxTypeNotSupported
+	}
+	payload, err := rlp.EncodeToBytes(tx.toDynamicFeeTxRLP())
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{tx.data.Type}, payload...), nil
+}
+
+func (tx *Transaction) decodeTyped(b []byte) error {
+	if len(b) == 0 {
+		return ErrTxTypeNotSupported
+	}
+	if b[0] == BlobTxType {
+		return tx.decodeBlobTx(b[1:])
+	}
+	if b[0] != DynamicFeeTxType {
+		return ErrTxTypeNotSupported
+	}
+	var inner dynamicFeeTxRLP
+	if err := rlp.DecodeBytes(b[1:], &inner); err != nil {
+		return err
+	}
+	if len(inner.AccessList) != 0 {
+		return ErrTxTypeNotSupported
+	}
+	tx.data = txdata{
+		AccountNonce: inner.Nonce,
+		Price:        inner.GasFeeCap,
+		GasLimit:     inner.Gas,
+		Recipient:    inner.To,
+		Amount:       inner.Value,
+		Payload:      inner.Data,
+		V:            inner.V,
+		R:            inner.R,
+		S:            inner.S,
+		Type:         DynamicFeeTxType,
+		ChainID:      inner.ChainID,
+		GasTipCap:    inner.GasTipCap,
+	}
+	return nil
+}
+
 // MarshalJSON encodes the web3 RPC transaction format.
 func (tx *Transaction) MarshalJSON() ([]byte, error) {
 	hash := tx.Hash()
@@ -128,7 +304,9 @@ func (tx *Transaction) UnmarshalJSON(input []byte) error {
 	withSignature := dec.V.Sign() != 0 || dec.R.Sign() != 0 || dec.S.Sign() != 0
 	if withSignature {
 		var V byte
-		if isProtectedV(dec.V) {
+		if dec.Type != LegacyTxType {
+			V = byte(dec.V.Uint64())
+		} else if isProtectedV(dec.V) {
 			chainID := deriveChainID(dec.V).Uint64()
 			V = byte(dec.V.Uint64() - 35 - 2*chainID)
 		} else {
@@ -149,9 +327,20 @@ func (tx *Transaction) Data() []byte { return common.CopyBytes(tx.data.Payload)
 // Gas tx gas
 func (tx *Transaction) Gas() uint64 { return tx.data.GasLimit }
 
-// GasPrice tx gas price
+// GasPrice tx gas price (max fee per gas for dynamic fee tx)
 func (tx *Transaction) GasPrice() *big.Int { return new(big.Int).Set(tx.data.Price) }
 
+// GasTipCap tx max priority fee per gas (gas price for legacy tx)
+func (tx *Transaction) GasTipCap() *big.Int {
+	if tx.data.Type == LegacyTxType {
+		return new(big.Int).Set(tx.data.Price)
+	}
+	return new(big.Int).Set(tx.data.GasTipCap)
+}
+
+// GasFeeCap tx max fee per gas (gas price for legacy tx)
+func (tx *Transaction) GasFeeCap() *big.Int { return new(big.Int).Set(tx.data.Price) }
+
 // Value tx value
 func (tx *Transaction) Value() *big.Int { return new(big.Int).Set(tx.data.Amount) }
 
@@ -178,13 +367,29 @@ func rlpHash(x interface{}) (h common.Hash) {
 	return h
 }
 
+func prefixedRlpHash(prefix byte, x interface{}) (h common.Hash) {
+	hw := sha3.NewLegacyKeccak256()
+	_, _ = hw.Write([]byte{prefix})
+	_ = rlp.Encode(hw, x)
+	hw.Sum(h[:0])
+	return h
+}
+
 // Hash hashes the RLP encoding of tx.
 // It uniquely identifies the transaction.
 func (tx *Transaction) Hash() common.Hash {
 	if hash := tx.hash.Load(); hash != nil {
 		return hash.(common.Hash)
 	}
-	v := rlpHash(tx)
+	var v common.Hash
+	switch tx.data.Type {
+	case LegacyTxType:
+		v = rlpHash(tx)
+	case BlobTxType:
+		v = prefixedRlpHash(tx.data.Type, tx.toBlobTxRLP())
+	default:
+		v = prefixedRlpHash(tx.data.Type, tx.toDynamicFeeTxRLP())
+	}
 	tx.hash.Store(v)
 	return v
 }
@@ -203,7 +408,11 @@ func (tx *Transaction) Size() StorageSize {
 		return size.(StorageSize)
 	}
 	c := writeCounter(0)
-	_ = rlp.Encode(&c, &tx.data)
+	if tx.data.Type == LegacyTxType {
+		_ = rlp.Encode(&c, &tx.data)
+	} else if enc, err := tx.encodeTyped(); err == nil {
+		c = writeCounter(len(enc))
+	}
 	tx.size.Store(StorageSize(c))
 	return StorageSize(c)
 }
@@ -220,10 +429,13 @@ func (tx *Transaction) WithSignature(signer Signer, sig []byte) (*Transaction, e
 	return cpy, nil
 }
 
-// Cost returns amount + gasprice * gaslimit.
+// Cost returns amount + gasprice * gaslimit (+ blobfeecap * blobgas for blob tx).
 func (tx *Transaction) Cost() *big.Int {
 	total := new(big.Int).Mul(tx.data.Price, new(big.Int).SetUint64(tx.data.GasLimit))
 	total.Add(total, tx.data.Amount)
+	if tx.data.BlobFeeCap != nil {
+		total.Add(total, new(big.Int).Mul(tx.data.BlobFeeCap, new(big.Int).SetUint64(tx.BlobGas())))
+	}
 	return total
 }
 
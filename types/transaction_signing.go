@@ -14,6 +14,8 @@ import (
 var (
 	ErrInvalidChainID = errors.New("invalid chain id for signer")
 	ErrInvalidSig     = errors.New("invalid transaction v, r, s values")
+
+	ErrTxTypeNotSupported = errors.New("transaction type not supported")
 )
 
 // sigCache is used to cache the derived sender and contains
@@ -27,6 +29,8 @@ type sigCache struct {
 func MakeSigner(signType string, chainID *big.Int) Signer {
 	var signer Signer
 	switch signType {
+	case "London":
+		signer = NewLondonSigner(chainID)
 	case "EIP155":
 		signer = NewEIP155Signer(chainID)
 	case "Homestead":
@@ -105,6 +109,11 @@ func NewEIP155Signer(chainID *big.Int) EIP155Signer {
 	}
 }
 
+// ChainID returns the chain id of signer
+func (s EIP155Signer) ChainID() *big.Int {
+	return new(big.Int).Set(s.chainID)
+}
+
 // Equal compare signer
 func (s EIP155Signer) Equal(s2 Signer) bool {
 	eip155, ok := s2.(EIP155Signer)
@@ -154,6 +163,92 @@ func (s EIP155Signer) Hash(tx *Transaction) common.Hash {
 	})
 }
 
+// LondonSigner implements Signer using the EIP1559 rules,
+// and legacy tx is handled by the EIP155 rules.
+type LondonSigner struct{ EIP155Signer }
+
+// NewLondonSigner new LondonSigner
+func NewLondonSigner(chainID *big.Int) LondonSigner {
+	return LondonSigner{NewEIP155Signer(chainID)}
+}
+
+// Equal compare signer
+func (s LondonSigner) Equal(s2 Signer) bool {
+	london, ok := s2.(LondonSigner)
+	return ok && london.chainID.Cmp(s.chainID) == 0
+}
+
+// Sender get sender
+func (s LondonSigner) Sender(tx *Transaction) (common.Address, error) {
+	if tx.Type() == LegacyTxType {
+		return s.EIP155Signer.Sender(tx)
+	}
+	if tx.Type() != DynamicFeeTxType && tx.Type() != BlobTxType {
+		return common.Address{}, ErrTxTypeNotSupported
+	}
+	if tx.ChainID().Cmp(s.chainID) != 0 {
+		return common.Address{}, ErrInvalidChainID
+	}
+	// typed tx use 0 and 1 as recovery id
+	V := new(big.Int).Add(tx.data.V, big.NewInt(27))
+	return recoverPlain(s.Hash(tx), tx.data.R, tx.data.S, V, true)
+}
+
+// SignatureValues returns signature values. This signature
+// needs to be in the [R || S || V] format where V is 0 or 1.
+func (s LondonSigner) SignatureValues(tx *Transaction, sig []byte) (rsvR, rsvS, rsvV *big.Int, err error) {
+	if tx.Type() == LegacyTxType {
+		return s.EIP155Signer.SignatureValues(tx, sig)
+	}
+	if tx.Type() != DynamicFeeTxType && tx.Type() != BlobTxType {
+		return nil, nil, nil, ErrTxTypeNotSupported
+	}
+	if tx.ChainID().Cmp(s.chainID) != 0 {
+		return nil, nil, nil, ErrInvalidChainID
+	}
+	rsvR, rsvS, _, err = HomesteadSigner{}.SignatureValues(tx, sig)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	rsvV = big.NewInt(int64(sig[64]))
+	return rsvR, rsvS, rsvV, nil
+}
+
+// Hash returns the hash to be signed by the sender.
+// It does not uniquely identify the transaction.
+func (s LondonSigner) Hash(tx *Transaction) common.Hash {
+	if tx.Type() == LegacyTxType {
+		return s.EIP155Signer.Hash(tx)
+	}
+	if tx.Type() == BlobTxType {
+		blobTx := tx.toBlobTxRLP()
+		return prefixedRlpHash(tx.Type(), []interface{}{
+			s.chainID,
+			blobTx.Nonce,
+			blobTx.GasTipCap,
+			blobTx.GasFeeCap,
+			blobTx.Gas,
+			blobTx.To,
+			blobTx.Value,
+			blobTx.Data,
+			blobTx.AccessList,
+			blobTx.BlobFeeCap,
+			blobTx.BlobHashes,
+		})
+	}
+	return prefixedRlpHash(tx.Type(), []interface{}{
+		s.chainID,
+		tx.data.AccountNonce,
+		tx.data.GasTipCap,
+		tx.data.Price,
+		tx.data.GasLimit,
+		tx.data.Recipient,
+		tx.data.Amount,
+		tx.data.Payload,
+		[]accessTuple{},
+	})
+}
+
 // HomesteadSigner implements TransactionInterface using the
 // homestead rules.
 type HomesteadSigner struct{ FrontierSigner }
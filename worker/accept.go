@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
 	"sync"
 	"time"
 
@@ -109,16 +110,19 @@ func verifySignInfo(signInfo *dcrm.SignInfoData) error {
 		if btc.BridgeInstance == nil {
 			return tokens.ErrNoBtcBridge
 		}
-		logWorker("accept", "verifySignInfo", "msgHash", msgHash, "msgContext", msgContext)
+		logWorker("accept", "verifySignInfo", "msgHash", msgHash, "pairID", args.PairID, "txid", args.SwapID, "from", args.From, "bind", args.Bind)
 		return btc.BridgeInstance.VerifyAggregateMsgHash(msgHash, &args)
 	default:
 		return errIdentifierMismatch
 	}
-	logWorker("accept", "verifySignInfo", "msgHash", msgHash, "msgContext", msgContext)
+	logWorker("accept", "verifySignInfo", "msgHash", msgHash, "pairID", args.PairID, "txid", args.SwapID, "from", args.From, "bind", args.Bind)
 	return rebuildAndVerifyMsgHash(msgHash, &args)
 }
 
 func rebuildAndVerifyMsgHash(msgHash []string, args *tokens.BuildTxArgs) error {
+	if args.TxType == tokens.RefundSwapoutTx {
+		return rebuildAndVerifyRefund(msgHash, args)
+	}
 	var srcBridge, dstBridge tokens.CrossChainBridge
 	switch args.SwapType {
 	case tokens.SwapinType:
@@ -142,12 +146,11 @@ func rebuildAndVerifyMsgHash(msgHash []string, args *tokens.BuildTxArgs) error {
 		return err
 	}
 
-	buildTxArgs := &tokens.BuildTxArgs{
-		SwapInfo:    args.SwapInfo,
-		From:        tokenCfg.DcrmAddress,
-		OriginValue: swapInfo.Value,
-		Extra:       args.Extra,
+	from := tokenCfg.DcrmAddress
+	if args.From != "" && tokenCfg.IsDcrmAccount(args.From) {
+		from = args.From // pooled dcrm account
 	}
+	buildTxArgs := newRebuildTxArgs(args, from, swapInfo.Value)
 	rawTx, err := dstBridge.BuildRawTransaction(buildTxArgs)
 	if err != nil {
 		return err
@@ -155,6 +158,18 @@ func rebuildAndVerifyMsgHash(msgHash []string, args *tokens.BuildTxArgs) error {
 	return dstBridge.VerifyMsgHash(rawTx, msgHash)
 }
 
+// newRebuildTxArgs rebuild args from the initiator's args (all args affecting msgHash should be kept)
+func newRebuildTxArgs(args *tokens.BuildTxArgs, from string, originValue *big.Int) *tokens.BuildTxArgs {
+	return &tokens.BuildTxArgs{
+		SwapInfo:    args.SwapInfo,
+		From:        from,
+		OriginValue: originValue,
+		Extra:       args.Extra,
+		NativeValue: args.NativeValue,
+		Deadline:    args.Deadline,
+	}
+}
+
 type acceptSignInfo struct {
 	keyID      string
 	result     string
@@ -0,0 +1,96 @@
+package worker
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/eth"
+	"github.com/anyswap/CrossChain-Bridge/types"
+)
+
+func TestRebuildSwapinWithDeadline(t *testing.T) {
+	pairID := "testdeadline"
+	zeroFeeRate := 0.0
+	decimals := uint8(18)
+	dcrmAddress := "0x2222222222222222222222222222222222222222"
+	tokens.SetTokenPairsConfig(map[string]*tokens.TokenPairConfig{
+		pairID: {
+			PairID:   pairID,
+			SrcToken: &tokens.TokenConfig{DcrmAddress: dcrmAddress, SwapFeeRate: &zeroFeeRate, Decimals: &decimals},
+			DestToken: &tokens.TokenConfig{
+				ContractAddress:        "0x1111111111111111111111111111111111111111",
+				DcrmAddress:            dcrmAddress,
+				SwapFeeRate:            &zeroFeeRate,
+				Decimals:               &decimals,
+				SwapinDeadlineFuncHash: "0x12345678",
+			},
+		},
+	}, false)
+	defer tokens.SetTokenPairsConfig(nil, false)
+
+	params.SetConfig(&params.ServerConfig{Identifier: "testidentifier"})
+	defer params.SetConfig(nil)
+
+	b := eth.NewCrossChainBridge(false)
+	b.ChainConfig = &tokens.ChainConfig{BlockChain: "ethereum", NetID: "test"}
+	b.GatewayConfig = &tokens.GatewayConfig{}
+	b.Signer = types.MakeSigner("London", big.NewInt(1))
+	err := b.SetTestStateProvider(&eth.FixedStateProvider{
+		GasPrice:     big.NewInt(1e9),
+		Balance:      new(big.Int).Lsh(big.NewInt(1), 100),
+		TokenBalance: new(big.Int).Lsh(big.NewInt(1), 100),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nonce, gas := uint64(7), uint64(90000)
+	args := &tokens.BuildTxArgs{
+		SwapInfo: tokens.SwapInfo{
+			PairID:   pairID,
+			SwapID:   "0x3333333333333333333333333333333333333333333333333333333333333333",
+			SwapType: tokens.SwapinType,
+			Bind:     "0x4444444444444444444444444444444444444444",
+		},
+		From:        dcrmAddress,
+		OriginValue: big.NewInt(1000),
+		Extra: &tokens.AllExtras{EthExtra: &tokens.EthExtraArgs{
+			Gas:      &gas,
+			GasPrice: big.NewInt(2e9),
+			Nonce:    &nonce,
+		}},
+		Deadline: time.Unix(2000000000, 0),
+	}
+	rawTx, err := b.BuildRawTransaction(args)
+	if err != nil {
+		t.Fatalf("build swapin tx failed: %v", err)
+	}
+	msgHash := []string{b.Signer.Hash(rawTx.(*types.Transaction)).String()}
+
+	// accept nodes receive the args as json msg context
+	var received tokens.BuildTxArgs
+	data, _ := json.Marshal(args.GetExtraArgs())
+	if err = json.Unmarshal(data, &received); err != nil {
+		t.Fatal(err)
+	}
+	rebuilt, err := b.BuildRawTransaction(newRebuildTxArgs(&received, dcrmAddress, big.NewInt(1000)))
+	if err != nil {
+		t.Fatalf("rebuild swapin tx failed: %v", err)
+	}
+	if err = b.VerifyMsgHash(rebuilt, msgHash); err != nil {
+		t.Errorf("rebuilt swapin with deadline: %v", err)
+	}
+
+	received.Deadline = time.Time{}
+	rebuilt, err = b.BuildRawTransaction(newRebuildTxArgs(&received, dcrmAddress, big.NewInt(1000)))
+	if err != nil {
+		t.Fatalf("rebuild swapin tx failed: %v", err)
+	}
+	if err = b.VerifyMsgHash(rebuilt, msgHash); err != tokens.ErrMsgHashMismatch {
+		t.Errorf("rebuilt swapin without deadline: want error %v, got %v", tokens.ErrMsgHashMismatch, err)
+	}
+}
@@ -0,0 +1,216 @@
+package worker
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+const defAnomalyCheckInterval = 60 * time.Second
+
+var anomalyDetector = &AnomalyDetector{}
+
+// AnomalyDetector pause building swaps of pairs when abnormal conditions are detected,
+// and keep them paused until operator resumes manually.
+type AnomalyDetector struct {
+	mu                 sync.Mutex
+	config             *params.AnomalyDetectorConfig
+	tripped            bool
+	reason             string
+	pausedPairs        []string
+	consecutiveReverts uint64
+	lastBalances       map[string]*big.Int
+}
+
+type gasPriceSuggester interface {
+	SuggestPrice() (*big.Int, error)
+}
+
+// StartAnomalyDetectJob periodically check anomaly rules if configed
+func StartAnomalyDetectJob() {
+	config := params.GetConfig().AnomalyDetector
+	if config == nil {
+		return
+	}
+	anomalyDetector.mu.Lock()
+	anomalyDetector.config = config
+	anomalyDetector.mu.Unlock()
+
+	interval := defAnomalyCheckInterval
+	if config.CheckInterval > 0 {
+		interval = time.Duration(config.CheckInterval) * time.Second
+	}
+	logWorker("anomaly", "start anomaly detect job", "interval", interval)
+	for {
+		anomalyDetector.check()
+		restInJob(interval)
+	}
+}
+
+// GetAnomalyStatus get whether anomaly detector is tripped and the reason
+func GetAnomalyStatus() (tripped bool, reason string) {
+	anomalyDetector.mu.Lock()
+	defer anomalyDetector.mu.Unlock()
+	return anomalyDetector.tripped, anomalyDetector.reason
+}
+
+// ResumeFromAnomaly enable pairs paused by anomaly detector and reset its state
+func ResumeFromAnomaly() {
+	d := anomalyDetector
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, pairID := range d.pausedPairs {
+		tokens.SetPairDisabled(pairID, false)
+	}
+	logWorker("anomaly", "resume from anomaly", "reason", d.reason, "pairs", d.pausedPairs)
+	d.tripped = false
+	d.reason = ""
+	d.pausedPairs = nil
+	d.consecutiveReverts = 0
+	d.lastBalances = nil
+}
+
+func recordSwapTxResult(txHash string, failed bool) {
+	d := anomalyDetector
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.config == nil {
+		return
+	}
+	if !failed {
+		d.consecutiveReverts = 0
+		return
+	}
+	d.consecutiveReverts++
+	maxReverts := d.config.MaxConsecutiveReverts
+	if maxReverts > 0 && d.consecutiveReverts >= maxReverts {
+		d.trip(fmt.Sprintf("%v swap txs failed consecutively, last is %v", d.consecutiveReverts, txHash))
+	}
+}
+
+func (d *AnomalyDetector) check() {
+	d.mu.Lock()
+	config := d.config
+	tripped := d.tripped
+	d.mu.Unlock()
+	if tripped || config == nil {
+		return
+	}
+
+	// query rpc without holding the lock
+	reason := checkGasPrice(config.MaxGasPrice)
+	var balances map[string]*big.Int
+	if reason == "" && config.MaxBalanceDropPercent > 0 {
+		balances = getDcrmBalances()
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.tripped {
+		return
+	}
+	if reason != "" {
+		d.trip(reason)
+		return
+	}
+	if balances == nil {
+		return
+	}
+	if reason = d.checkBalanceDrop(config.MaxBalanceDropPercent, balances); reason != "" {
+		d.trip(reason)
+	}
+}
+
+func checkGasPrice(maxGasPrice uint64) string {
+	if maxGasPrice == 0 {
+		return ""
+	}
+	limit := new(big.Int).SetUint64(maxGasPrice)
+	for _, bridge := range []tokens.CrossChainBridge{tokens.SrcBridge, tokens.DstBridge} {
+		suggester, ok := bridge.(gasPriceSuggester)
+		if !ok {
+			continue
+		}
+		gasPrice, err := suggester.SuggestPrice()
+		if err != nil {
+			logWorkerError("anomaly", "get gas price failed", err, "blockChain", bridge.GetChainConfig().BlockChain)
+			continue
+		}
+		if gasPrice.Cmp(limit) > 0 {
+			return fmt.Sprintf("gas price %v of %v exceeds %v", gasPrice, bridge.GetChainConfig().BlockChain, limit)
+		}
+	}
+	return ""
+}
+
+func getDcrmBalances() map[string]*big.Int {
+	balances := make(map[string]*big.Int)
+	for _, pairID := range tokens.GetAllPairIDs() {
+		for _, isSrc := range []bool{true, false} {
+			bridge := tokens.GetCrossChainBridge(isSrc)
+			tokenCfg := bridge.GetTokenConfig(pairID)
+			if tokenCfg == nil || tokenCfg.DcrmAddress == "" {
+				continue
+			}
+			key := fmt.Sprintf("%v:%v", isSrc, strings.ToLower(tokenCfg.DcrmAddress))
+			if _, exist := balances[key]; exist {
+				continue
+			}
+			balance, err := bridge.GetBalance(tokenCfg.DcrmAddress)
+			if err != nil {
+				logWorkerError("anomaly", "get balance failed", err, "account", tokenCfg.DcrmAddress, "isSrc", isSrc)
+				continue
+			}
+			balances[key] = balance
+		}
+	}
+	return balances
+}
+
+// checkBalanceDrop should be called with lock held
+func (d *AnomalyDetector) checkBalanceDrop(maxDropPercent float64, balances map[string]*big.Int) string {
+	defer func() { d.lastBalances = balances }()
+	for key, balance := range balances {
+		lastBalance := d.lastBalances[key]
+		if lastBalance == nil || lastBalance.Sign() <= 0 || balance.Cmp(lastBalance) >= 0 {
+			continue
+		}
+		drop := new(big.Float).SetInt(new(big.Int).Sub(lastBalance, balance))
+		dropPercent, _ := new(big.Float).Quo(drop, new(big.Float).SetInt(lastBalance)).Float64()
+		dropPercent *= 100
+		if dropPercent > maxDropPercent {
+			return fmt.Sprintf("balance of %v dropped %.2f%% from %v to %v", key, dropPercent, lastBalance, balance)
+		}
+	}
+	return ""
+}
+
+// trip should be called with lock held
+func (d *AnomalyDetector) trip(reason string) {
+	if d.tripped {
+		return
+	}
+	pairIDs := d.config.PausePairs
+	if len(pairIDs) == 0 {
+		pairIDs = tokens.GetAllPairIDs()
+	}
+	for _, pairID := range pairIDs {
+		if tokens.IsPairDisabled(pairID) {
+			continue // keep pairs disabled by others untouched when resuming
+		}
+		tokens.SetPairDisabled(pairID, true)
+		d.pausedPairs = append(d.pausedPairs, pairID)
+	}
+	d.tripped = true
+	d.reason = reason
+	logWorkerError("anomaly", "anomaly detected, pause swaps", fmt.Errorf("%v", reason), "pairs", d.pausedPairs)
+	publishEvent(&Event{
+		Type:   EventAnomalyDetected,
+		Reason: reason,
+	})
+}
@@ -0,0 +1,160 @@
+package worker
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+var (
+	swapoutBatches     = make(map[string]*swapoutBatch) // key is pairID
+	swapoutBatchesLock sync.Mutex
+
+	swapoutBatchTaskChanMap = make(map[string]chan []*tokens.BuildTxArgs) // key is dcrm address
+)
+
+type swapoutBatch struct {
+	args  []*tokens.BuildTxArgs
+	keys  map[string]struct{}
+	timer *time.Timer
+}
+
+// isBatchSwapout batch swapout if 'BatchWindow' is configed and the bridge
+// supports building batch swapout tx with private key or KMS signing
+func isBatchSwapout(args *tokens.BuildTxArgs) bool {
+	if args.SwapType != tokens.SwapoutType {
+		return false
+	}
+	if _, ok := tokens.SrcBridge.(tokens.BatchSwapoutBuilder); !ok {
+		return false
+	}
+	tokenCfg := tokens.SrcBridge.GetTokenConfig(args.PairID)
+	if tokenCfg == nil || tokenCfg.BatchWindow == 0 {
+		return false
+	}
+	return tokenCfg.GetDcrmAddressPrivateKey() != nil || tokenCfg.IsKmsSign()
+}
+
+// addBatchSwapout collect swapout into batch of its pair,
+// flush the batch when 'BatchWindow' elapsed or 'MaxBatchSize' is reached
+func addBatchSwapout(args *tokens.BuildTxArgs) {
+	tokenCfg := tokens.SrcBridge.GetTokenConfig(args.PairID)
+	pairID := strings.ToLower(args.PairID)
+	key := strings.ToLower(args.SwapID + ":" + args.Bind)
+
+	swapoutBatchesLock.Lock()
+	defer swapoutBatchesLock.Unlock()
+
+	batch, exist := swapoutBatches[pairID]
+	if !exist {
+		batch = &swapoutBatch{keys: make(map[string]struct{})}
+		swapoutBatches[pairID] = batch
+		window := time.Duration(tokenCfg.BatchWindow) * time.Second
+		batch.timer = time.AfterFunc(window, func() { flushBatchSwapout(pairID, batch) })
+	}
+	if _, exist := batch.keys[key]; exist {
+		return
+	}
+	batch.keys[key] = struct{}{}
+	batch.args = append(batch.args, args)
+	logWorker("batch", "add swapout to batch", "pairID", pairID, "txid", args.SwapID, "bind", args.Bind, "size", len(batch.args))
+
+	if len(batch.args) >= tokenCfg.GetMaxBatchSize() {
+		batch.timer.Stop()
+		go flushBatchSwapout(pairID, batch)
+	}
+}
+
+func flushBatchSwapout(pairID string, batch *swapoutBatch) {
+	swapoutBatchesLock.Lock()
+	if swapoutBatches[pairID] != batch {
+		swapoutBatchesLock.Unlock()
+		return // already flushed
+	}
+	delete(swapoutBatches, pairID)
+	swapoutBatchesLock.Unlock()
+
+	if len(batch.args) == 0 {
+		return
+	}
+	from := strings.ToLower(batch.args[0].From)
+	batchChan, exist := swapoutBatchTaskChanMap[from]
+	if !exist {
+		logWorkerWarn("batch", "no batch task channel for dcrm address", "from", from, "pairID", pairID)
+		return
+	}
+	logWorker("batch", "flush swapout batch", "pairID", pairID, "size", len(batch.args))
+	batchChan <- batch.args
+}
+
+// doBatchSwapout build, sign and send one tx for a batch of swapouts
+func doBatchSwapout(batch []*tokens.BuildTxArgs) (err error) {
+	var valid []*tokens.BuildTxArgs
+	for _, args := range batch {
+		res, errf := mongodb.FindSwapResult(false, args.SwapID, args.PairID, args.Bind)
+		if errf != nil {
+			logWorkerError("batch", "find swap result failed", errf, "txid", args.SwapID, "bind", args.Bind)
+			continue
+		}
+		if errf = preventReswap(res, false); errf != nil {
+			continue
+		}
+		valid = append(valid, args)
+	}
+	switch len(valid) {
+	case 0:
+		return nil
+	case 1:
+		return doSwap(valid[0])
+	}
+
+	first := valid[0]
+	pairID := first.PairID
+	resBridge := tokens.SrcBridge
+	builder := resBridge.(tokens.BatchSwapoutBuilder)
+
+	rawTx, err := builder.BuildBatchSwapoutTx(valid)
+	if err != nil {
+		logWorkerError("batch", "build batch swapout tx failed", err, "pairID", pairID, "size", len(valid))
+		return err
+	}
+	signedTx, txHash, err := resBridge.SignTransaction(rawTx, pairID)
+	if err != nil {
+		logWorkerError("batch", "sign batch swapout tx failed", err, "pairID", pairID, "size", len(valid))
+		return err
+	}
+	trackUnsentSignedTx(resBridge, signedTx, first, txHash, first.From)
+	defer untrackUnsentSignedTx(txHash)
+
+	swapTxNonce := first.GetTxNonce()
+	for _, args := range valid {
+		addSwapHistory(args.SwapID, args.Bind, args.OriginValue, txHash, swapTxNonce, false)
+		matchTx := &MatchTx{
+			SwapTx:    txHash,
+			SwapValue: tokens.CalcSwappedValue(args.PairID, args.OriginValue, false).String(),
+			SwapType:  args.SwapType,
+			SwapNonce: swapTxNonce,
+		}
+		err = updateSwapResult(args.SwapID, args.PairID, args.Bind, matchTx)
+		if err != nil {
+			logWorkerError("batch", "update swap result failed", err, "txid", args.SwapID, "bind", args.Bind)
+			return err
+		}
+		_ = mongodb.UpdateSwapStatus(false, args.SwapID, args.PairID, args.Bind, mongodb.TxProcessed, now(), "")
+	}
+	logWorker("batch", "send batch swapout tx", "pairID", pairID, "size", len(valid), "swaptx", txHash)
+
+	err = sendSignedTransaction(resBridge, signedTx, first)
+	if err != nil {
+		for _, args := range valid[1:] {
+			_ = mongodb.UpdateSwapStatus(false, args.SwapID, args.PairID, args.Bind, mongodb.TxSwapFailed, now(), err.Error())
+			_ = mongodb.UpdateSwapResultStatus(false, args.SwapID, args.PairID, args.Bind, mongodb.TxSwapFailed, now(), err.Error())
+		}
+		return err
+	}
+	addSignedTx(resBridge, signedTx, first, txHash, first.From)
+	return nil
+}
@@ -0,0 +1,80 @@
+package worker
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+// block send counters of sender address, key is lower case address
+var (
+	blockSendCounters     = make(map[string]*blockSendCounter)
+	blockSendCountersLock sync.Mutex
+)
+
+// blockSendCounter count txs sent in the current head block
+type blockSendCounter struct {
+	blockNumber uint64
+	count       uint64
+}
+
+// take a send slot of the head block, return false if the head block is full
+func (c *blockSendCounter) take(head, maxTxs uint64) bool {
+	if head > c.blockNumber {
+		c.blockNumber = head
+		c.count = 0
+	}
+	if c.count >= maxTxs {
+		return false
+	}
+	c.count++
+	return true
+}
+
+// waitBlockSendSlot defer sending to next block if 'MaxTxsPerBlock' txs
+// of the sender address have been sent in the current head block.
+// do not block sending if head is unavailable or not advancing for long.
+func waitBlockSendSlot(bridge tokens.CrossChainBridge, args *tokens.BuildTxArgs) {
+	tokenCfg := bridge.GetTokenConfig(args.PairID)
+	if tokenCfg == nil || tokenCfg.MaxTxsPerBlock == 0 {
+		return
+	}
+	from := args.From
+	if from == "" {
+		from = tokenCfg.DcrmAddress
+	}
+	key := strings.ToLower(from)
+
+	blockTime := bridge.GetChainConfig().GetAverageBlockTime()
+	pollInterval := blockTime / 3
+	if pollInterval < time.Second {
+		pollInterval = time.Second
+	}
+	deadline := time.Now().Add(5 * blockTime)
+	for {
+		head, err := bridge.GetLatestBlockNumber()
+		if err != nil {
+			logWorkerWarn("sendtx", "get latest block number failed, skip block send limit", "from", from, "err", err)
+			return
+		}
+		blockSendCountersLock.Lock()
+		counter, exist := blockSendCounters[key]
+		if !exist {
+			counter = &blockSendCounter{}
+			blockSendCounters[key] = counter
+		}
+		ok := counter.take(head, tokenCfg.MaxTxsPerBlock)
+		blockSendCountersLock.Unlock()
+		if ok {
+			return
+		}
+		if time.Now().After(deadline) {
+			logWorkerWarn("sendtx", "head block is not advancing, skip block send limit", "from", from, "head", head)
+			return
+		}
+		logWorkerTrace("sendtx", "defer sending tx to next block", "from", from, "head", head, "maxTxsPerBlock", tokenCfg.MaxTxsPerBlock)
+		time.Sleep(pollInterval)
+	}
+}
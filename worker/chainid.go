@@ -0,0 +1,30 @@
+package worker
+
+import (
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+var restIntervalInChainIDCheckJob = 5 * time.Minute
+
+// StartChainIDCheckJob periodically check gateway chain id of bridges
+func StartChainIDCheckJob() {
+	logWorker("chainid", "start check chain id job")
+	for {
+		checkChainID(tokens.SrcBridge, "src")
+		checkChainID(tokens.DstBridge, "dst")
+		restInJob(restIntervalInChainIDCheckJob)
+	}
+}
+
+func checkChainID(bridge tokens.CrossChainBridge, side string) {
+	checker, ok := bridge.(tokens.ChainIDChecker)
+	if !ok {
+		return
+	}
+	err := checker.CheckChainID()
+	if err != nil {
+		logWorkerError("chainid", "check chain id failed", err, "side", side, "blockChain", bridge.GetChainConfig().BlockChain)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/params"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
 	"github.com/anyswap/CrossChain-Bridge/tokens/btc"
 )
@@ -146,26 +147,26 @@ func verifySwapTransaction(bridge tokens.CrossChainBridge, pairID, txid, bind st
 }
 
 func dcrmSignTransaction(bridge tokens.CrossChainBridge, rawTx interface{}, args *tokens.BuildTxArgs) (signedTx interface{}, txHash string, err error) {
-	maxRetryDcrmSignCount := 5
-	for i := 0; i < maxRetryDcrmSignCount; i++ {
-		signedTx, txHash, err = bridge.DcrmSignTransaction(rawTx, args.GetExtraArgs())
-		if err == nil {
-			break
-		}
-	}
-	if err != nil {
-		return nil, "", err
-	}
-	return signedTx, txHash, nil
+	return dcrmSignWithRetry(bridge, rawTx, args, params.GetDcrmSignRetryCount(), params.GetDcrmSignRetryInterval())
 }
 
-func sendSignedTransaction(bridge tokens.CrossChainBridge, signedTx interface{}, txid, pairID, bind string, isSwapin bool) (err error) {
+func sendSignedTransaction(bridge tokens.CrossChainBridge, signedTx interface{}, args *tokens.BuildTxArgs) (err error) {
 	var (
+		txid                = args.SwapID
+		pairID              = args.PairID
+		bind                = args.Bind
+		isSwapin            = args.SwapType == tokens.SwapinType
 		txHash              string
 		retrySendTxCount    = 3
 		retrySendTxInterval = 1 * time.Second
 	)
+	waitBlockSendSlot(bridge, args)
 	for i := 0; i < retrySendTxCount; i++ {
+		if args.IsExpired() {
+			logWorkerWarn("sendtx", "refuse sending tx after deadline", "txid", txid, "bind", bind, "isSwapin", isSwapin, "deadline", args.Deadline)
+			err = tokens.ErrTxExpired
+			break
+		}
 		txHash, err = bridge.SendTransaction(signedTx)
 		if txHash != "" {
 			if tx, _ := bridge.GetTransaction(txHash); tx != nil {
@@ -182,8 +183,24 @@ func sendSignedTransaction(bridge tokens.CrossChainBridge, signedTx interface{},
 		_ = mongodb.UpdateSwapResultStatus(isSwapin, txid, pairID, bind, mongodb.TxSwapFailed, now(), err.Error())
 		return err
 	}
-	if nonceSetter, ok := bridge.(tokens.NonceSetter); ok {
+	// local nonce is only maintained for 'DcrmAddress' (not pooled accounts)
+	if nonceSetter, ok := bridge.(tokens.NonceSetter); ok && isSentFromDcrmAddress(bridge, args) {
 		nonceSetter.IncreaseNonce(pairID, 1)
 	}
+	trackPendingTx(txHash, txid, pairID, bind, args.From, args.GetTxNonce(), isSwapin, args.TraceID)
+	publishEvent(&Event{
+		Type:     EventSwapTxSent,
+		TxID:     txid,
+		PairID:   pairID,
+		Bind:     bind,
+		IsSwapin: isSwapin,
+		SwapTx:   txHash,
+		TraceID:  args.TraceID,
+	})
 	return nil
 }
+
+func isSentFromDcrmAddress(bridge tokens.CrossChainBridge, args *tokens.BuildTxArgs) bool {
+	tokenCfg := bridge.GetTokenConfig(args.PairID)
+	return args.From == "" || tokenCfg == nil || strings.EqualFold(args.From, tokenCfg.DcrmAddress)
+}
@@ -0,0 +1,63 @@
+package worker
+
+import (
+	"sync"
+)
+
+// EventType type of worker event
+type EventType string
+
+// worker event types
+const (
+	EventSwapTxSent           EventType = "SwapTxSent"
+	EventSwapTxPendingTooLong EventType = "SwapTxPendingTooLong"
+	EventSwapTxDropped        EventType = "SwapTxDropped"
+	EventSwapRefunded         EventType = "SwapRefunded"
+	EventAnomalyDetected      EventType = "AnomalyDetected"
+)
+
+// Event is published by worker for alerting and tracing
+type Event struct {
+	Type      EventType
+	TxID      string
+	PairID    string
+	Bind      string
+	IsSwapin  bool
+	SwapTx    string
+	TraceID   string
+	Reason    string
+	Timestamp int64
+}
+
+var (
+	eventSubscribers     = make(map[chan<- *Event]struct{})
+	eventSubscribersLock sync.RWMutex
+)
+
+// SubscribeEvents subscribe worker events, return the unsubscribe func.
+// events are dropped if the channel is full, so workers are never blocked.
+func SubscribeEvents(ch chan<- *Event) (unsubscribe func()) {
+	eventSubscribersLock.Lock()
+	defer eventSubscribersLock.Unlock()
+	eventSubscribers[ch] = struct{}{}
+	return func() {
+		eventSubscribersLock.Lock()
+		defer eventSubscribersLock.Unlock()
+		delete(eventSubscribers, ch)
+	}
+}
+
+func publishEvent(event *Event) {
+	if event.Timestamp == 0 {
+		event.Timestamp = now()
+	}
+	eventSubscribersLock.RLock()
+	defer eventSubscribersLock.RUnlock()
+	for ch := range eventSubscribers {
+		select {
+		case ch <- event:
+		default:
+			logWorkerWarn("event", "drop event as subscriber is busy", "type", event.Type, "txid", event.TxID, "pairID", event.PairID)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+type pendingTestBridge struct {
+	tokens.CrossChainBridge
+}
+
+func (b *pendingTestBridge) GetTransactionStatus(txHash string) *tokens.TxStatus {
+	return &tokens.TxStatus{}
+}
+
+func receiveEvent(t *testing.T, events chan *Event) *Event {
+	select {
+	case event := <-events:
+		return event
+	default:
+		t.Fatal("no event is delivered")
+	}
+	return nil
+}
+
+func TestAnomalyDetectedEvent(t *testing.T) {
+	events := make(chan *Event, 1)
+	unsubscribe := SubscribeEvents(events)
+	defer unsubscribe()
+
+	anomalyDetector.mu.Lock()
+	anomalyDetector.config = &params.AnomalyDetectorConfig{MaxConsecutiveReverts: 2}
+	anomalyDetector.mu.Unlock()
+	defer func() {
+		ResumeFromAnomaly()
+		anomalyDetector.mu.Lock()
+		anomalyDetector.config = nil
+		anomalyDetector.mu.Unlock()
+	}()
+
+	recordSwapTxResult("0x01", true)
+	if len(events) != 0 {
+		t.Fatal("should not trip before reaching max consecutive reverts")
+	}
+	recordSwapTxResult("0x02", true)
+	event := receiveEvent(t, events)
+	if event.Type != EventAnomalyDetected || event.Reason == "" || event.Timestamp == 0 {
+		t.Errorf("wrong anomaly event %+v", event)
+	}
+	if tripped, reason := GetAnomalyStatus(); !tripped || reason != event.Reason {
+		t.Errorf("wrong anomaly status, tripped %v reason %v", tripped, reason)
+	}
+}
+
+func TestUnsubscribeEvents(t *testing.T) {
+	events := make(chan *Event, 1)
+	unsubscribe := SubscribeEvents(events)
+	unsubscribe()
+
+	publishEvent(&Event{Type: EventSwapRefunded})
+	if len(events) != 0 {
+		t.Errorf("should not deliver event after unsubscribed")
+	}
+}
+
+func TestSwapTxPendingTooLongEventWithTraceID(t *testing.T) {
+	events := make(chan *Event, 1)
+	unsubscribe := SubscribeEvents(events)
+	defer unsubscribe()
+
+	oldDstBridge := tokens.DstBridge
+	tokens.DstBridge = &pendingTestBridge{}
+	defer func() { tokens.DstBridge = oldDstBridge }()
+
+	args := &tokens.BuildTxArgs{SwapInfo: tokens.SwapInfo{PairID: "testpair", SwapID: "0x01", Bind: "0x02"}}
+	traceID := args.EnsureTraceID()
+	pendingTxsLock.Lock()
+	pendingTxs["0x03"] = &pendingTx{
+		txHash:     "0x03",
+		txid:       args.SwapID,
+		pairID:     args.PairID,
+		bind:       args.Bind,
+		isSwapin:   true,
+		traceID:    traceID,
+		submitTime: time.Now().Add(-time.Hour),
+	}
+	pendingTxsLock.Unlock()
+	defer func() {
+		pendingTxsLock.Lock()
+		delete(pendingTxs, "0x03")
+		pendingTxsLock.Unlock()
+	}()
+
+	checkPendingTxs(time.Minute)
+	event := receiveEvent(t, events)
+	if event.Type != EventSwapTxPendingTooLong || event.SwapTx != "0x03" || event.TxID != args.SwapID {
+		t.Errorf("wrong pending event %+v", event)
+	}
+	if event.TraceID == "" || event.TraceID != traceID {
+		t.Errorf("pending event: want trace id %v, got %v", traceID, event.TraceID)
+	}
+
+	// alert only once
+	checkPendingTxs(time.Minute)
+	if len(events) != 0 {
+		t.Errorf("should alert pending tx only once")
+	}
+}
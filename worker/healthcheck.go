@@ -0,0 +1,98 @@
+package worker
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+// CheckHealth diagnose problems of bridges, return empty if healthy
+func CheckHealth() (problems []string) {
+	problems = append(problems, checkDuplicateNonces(tokens.SrcBridge, "src", true)...)
+	problems = append(problems, checkDuplicateNonces(tokens.DstBridge, "dst", false)...)
+	problems = append(problems, checkPausedContracts(tokens.SrcBridge, "src", true)...)
+	problems = append(problems, checkPausedContracts(tokens.DstBridge, "dst", false)...)
+	return problems
+}
+
+// checkPausedContracts check whether contracts with configured paused view are paused
+func checkPausedContracts(bridge tokens.CrossChainBridge, side string, isSrc bool) (problems []string) {
+	checker, ok := bridge.(tokens.ContractPausedChecker)
+	if !ok {
+		return nil
+	}
+	exist := make(map[string]struct{})
+	for _, pairCfg := range tokens.GetTokenPairsConfig() {
+		tokenCfg := pairCfg.DestToken
+		if isSrc {
+			tokenCfg = pairCfg.SrcToken
+		}
+		if tokenCfg == nil || tokenCfg.PausedFuncHash == "" || tokenCfg.ContractAddress == "" {
+			continue
+		}
+		key := strings.ToLower(tokenCfg.ContractAddress)
+		if _, ok := exist[key]; ok {
+			continue
+		}
+		exist[key] = struct{}{}
+		paused, err := checker.IsContractPaused(tokenCfg.ContractAddress)
+		if err != nil {
+			logWorkerWarn("healthcheck", "get contract paused state failed", "side", side, "contract", tokenCfg.ContractAddress, "err", err)
+			continue
+		}
+		if paused {
+			logWorkerWarn("healthcheck", "found paused contract", "side", side, "pairID", pairCfg.PairID, "contract", tokenCfg.ContractAddress)
+			problems = append(problems, fmt.Sprintf("%v contract %v is paused", side, tokenCfg.ContractAddress))
+		}
+	}
+	return problems
+}
+
+// checkDuplicateNonces check whether txs of dcrm addresses share one nonce in txpool
+func checkDuplicateNonces(bridge tokens.CrossChainBridge, side string, isSrc bool) (problems []string) {
+	detector, ok := bridge.(tokens.DuplicateNonceDetector)
+	if !ok {
+		return nil
+	}
+	for _, address := range getDcrmAddresses(isSrc) {
+		duplicates, err := detector.GetDuplicateNonces(address)
+		if err != nil {
+			if err != tokens.ErrTxPoolNotSupported {
+				logWorkerWarn("healthcheck", "get duplicate nonces failed", "side", side, "address", address, "err", err)
+			}
+			continue
+		}
+		nonces := make([]uint64, 0, len(duplicates))
+		for nonce := range duplicates {
+			nonces = append(nonces, nonce)
+		}
+		sort.Slice(nonces, func(i, j int) bool { return nonces[i] < nonces[j] })
+		for _, nonce := range nonces {
+			txHashes := duplicates[nonce]
+			logWorkerWarn("healthcheck", "found duplicate nonce", "side", side, "address", address, "nonce", nonce, "txs", txHashes)
+			problems = append(problems, fmt.Sprintf("%v address %v nonce %v has txs %v", side, address, nonce, strings.Join(txHashes, ",")))
+		}
+	}
+	return problems
+}
+
+func getDcrmAddresses(isSrc bool) (addresses []string) {
+	exist := make(map[string]struct{})
+	for _, pairCfg := range tokens.GetTokenPairsConfig() {
+		tokenCfg := pairCfg.DestToken
+		if isSrc {
+			tokenCfg = pairCfg.SrcToken
+		}
+		for _, account := range tokenCfg.GetDcrmAccounts() {
+			key := strings.ToLower(account)
+			if _, ok := exist[key]; ok {
+				continue
+			}
+			exist[key] = struct{}{}
+			addresses = append(addresses, account)
+		}
+	}
+	return addresses
+}
@@ -0,0 +1,150 @@
+package worker
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+var (
+	pendingTxs     = make(map[string]*pendingTx) // key is tx hash
+	pendingTxsLock sync.Mutex
+
+	restIntervalInPendingMonitorJob = 30 * time.Second
+)
+
+type pendingTx struct {
+	txHash     string
+	txid       string
+	pairID     string
+	bind       string
+	from       string
+	nonce      uint64
+	isSwapin   bool
+	traceID    string
+	submitTime time.Time
+	alerted    bool
+}
+
+func getMaxPendingAge() time.Duration {
+	return time.Duration(params.GetConfig().MaxPendingAge) * time.Second
+}
+
+// trackPendingTx track sent swap tx until it is confirmed
+func trackPendingTx(txHash, txid, pairID, bind, from string, nonce uint64, isSwapin bool, traceID string) {
+	if getMaxPendingAge() == 0 || txHash == "" {
+		return
+	}
+	pendingTxsLock.Lock()
+	defer pendingTxsLock.Unlock()
+	if _, exist := pendingTxs[txHash]; exist {
+		return
+	}
+	pendingTxs[txHash] = &pendingTx{
+		txHash:     txHash,
+		txid:       txid,
+		pairID:     pairID,
+		bind:       bind,
+		from:       from,
+		nonce:      nonce,
+		isSwapin:   isSwapin,
+		traceID:    traceID,
+		submitTime: time.Now(),
+	}
+}
+
+// StartPendingTxMonitorJob alert if sent swap tx is pending longer than 'MaxPendingAge'
+func StartPendingTxMonitorJob() {
+	maxPendingAge := getMaxPendingAge()
+	if maxPendingAge == 0 {
+		return
+	}
+	logWorker("pending", "start pending tx monitor job", "maxPendingAge", maxPendingAge)
+	for {
+		checkPendingTxs(maxPendingAge)
+		restInJob(restIntervalInPendingMonitorJob)
+	}
+}
+
+func checkPendingTxs(maxPendingAge time.Duration) {
+	pendingTxsLock.Lock()
+	txs := make([]*pendingTx, 0, len(pendingTxs))
+	for _, tx := range pendingTxs {
+		txs = append(txs, tx)
+	}
+	pendingTxsLock.Unlock()
+
+	for _, tx := range txs {
+		resBridge := tokens.GetCrossChainBridge(!tx.isSwapin)
+		txStatus := resBridge.GetTransactionStatus(tx.txHash)
+		if txStatus != nil && txStatus.BlockHeight > 0 {
+			pendingTxsLock.Lock()
+			delete(pendingTxs, tx.txHash)
+			pendingTxsLock.Unlock()
+			continue
+		}
+		if checkPendingTxDropped(resBridge, tx) {
+			pendingTxsLock.Lock()
+			delete(pendingTxs, tx.txHash)
+			pendingTxsLock.Unlock()
+			continue
+		}
+		age := time.Since(tx.submitTime)
+		if age > maxPendingAge && !tx.alerted {
+			tx.alerted = true
+			logWorkerError("pending", "swap tx is pending too long", tokens.ErrTxPendingTooLong,
+				"swaptx", tx.txHash, "txid", tx.txid, "pairID", tx.pairID, "bind", tx.bind,
+				"isSwapin", tx.isSwapin, "age", age.Round(time.Second), "traceID", tx.traceID)
+			publishEvent(&Event{
+				Type:     EventSwapTxPendingTooLong,
+				TxID:     tx.txid,
+				PairID:   tx.pairID,
+				Bind:     tx.bind,
+				IsSwapin: tx.isSwapin,
+				SwapTx:   tx.txHash,
+				TraceID:  tx.traceID,
+				Reason:   fmt.Sprintf("pending for %v", age.Round(time.Second)),
+			})
+		}
+	}
+}
+
+// checkPendingTxDropped return true if the pending tx is dropped and can never be mined
+func checkPendingTxDropped(resBridge tokens.CrossChainBridge, tx *pendingTx) bool {
+	detector, ok := resBridge.(tokens.TxDropDetector)
+	if !ok || tx.from == "" {
+		return false
+	}
+	err := detector.CheckTxDropped(tx.txHash, tx.from, tx.nonce)
+	if err != tokens.ErrTxDropped {
+		return false
+	}
+	logWorkerError("pending", "swap tx is dropped", err,
+		"swaptx", tx.txHash, "txid", tx.txid, "pairID", tx.pairID, "bind", tx.bind,
+		"isSwapin", tx.isSwapin, "from", tx.from, "nonce", tx.nonce, "traceID", tx.traceID)
+	publishEvent(&Event{
+		Type:     EventSwapTxDropped,
+		TxID:     tx.txid,
+		PairID:   tx.pairID,
+		Bind:     tx.bind,
+		IsSwapin: tx.isSwapin,
+		SwapTx:   tx.txHash,
+		TraceID:  tx.traceID,
+		Reason:   err.Error(),
+	})
+	if !params.GetConfig().MarkDroppedTxFailed {
+		return true
+	}
+	// the swap may be already replaced with another tx (eg. by replace-by-fee)
+	res, errf := mongodb.FindSwapResult(tx.isSwapin, tx.txid, tx.pairID, tx.bind)
+	if errf != nil || !strings.EqualFold(res.SwapTx, tx.txHash) {
+		return true
+	}
+	_ = markSwapResultFailed(tx.txid, tx.pairID, tx.bind, tx.isSwapin)
+	return true
+}
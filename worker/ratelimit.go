@@ -0,0 +1,110 @@
+package worker
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+var (
+	swapRateLimiters     = make(map[string]*rateLimiter)
+	swapRateLimitersLock sync.Mutex
+)
+
+// rateLimiter token bucket rate limiter
+type rateLimiter struct {
+	lock     sync.Mutex
+	rate     float64 // tokens per second
+	burst    float64
+	tokens   float64
+	lastTime time.Time
+}
+
+func newRateLimiter(ratePerMinute float64, burst uint64) *rateLimiter {
+	l := &rateLimiter{lastTime: time.Now()}
+	l.setLimit(ratePerMinute, burst)
+	l.tokens = l.burst
+	return l
+}
+
+// setLimit apply the current config (config may be reloaded)
+func (l *rateLimiter) setLimit(ratePerMinute float64, burst uint64) {
+	if burst == 0 {
+		burst = 1
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.rate = ratePerMinute / 60
+	l.burst = float64(burst)
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+}
+
+// take take one token, if not success return the duration to wait
+func (l *rateLimiter) take() (wait time.Duration, ok bool) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := time.Now()
+	elapsed := now.Sub(l.lastTime).Seconds()
+	l.lastTime = now
+	l.tokens = math.Min(l.burst, l.tokens+elapsed*l.rate)
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0, true
+	}
+	waitSeconds := (1 - l.tokens) / l.rate
+	return time.Duration(waitSeconds * float64(time.Second)), false
+}
+
+// giveBack return the token taken by a swap which is not sent
+func (l *rateLimiter) giveBack() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.tokens = math.Min(l.burst, l.tokens+1)
+}
+
+func getSwapRateLimiter(pairID string, isSrc bool, tokenCfg *tokens.TokenConfig) *rateLimiter {
+	key := strings.ToLower(pairID)
+	if isSrc {
+		key += ":src"
+	} else {
+		key += ":dst"
+	}
+	swapRateLimitersLock.Lock()
+	defer swapRateLimitersLock.Unlock()
+	limiter, exist := swapRateLimiters[key]
+	if !exist {
+		limiter = newRateLimiter(tokenCfg.SwapRateLimit, tokenCfg.SwapRateBurst)
+		swapRateLimiters[key] = limiter
+	} else {
+		limiter.setLimit(tokenCfg.SwapRateLimit, tokenCfg.SwapRateBurst)
+	}
+	return limiter
+}
+
+// waitSwapRateLimit wait until swap tx of pairID is allowed by rate limit,
+// return the limiter (nil if not limited) to give back the token if the swap is not sent.
+// return ErrRateLimited if it's not allowed within 'SwapRateWaitSeconds'.
+func waitSwapRateLimit(pairID string, isSrc bool) (*rateLimiter, error) {
+	tokenCfg := tokens.GetTokenConfig(pairID, isSrc)
+	if tokenCfg == nil || tokenCfg.SwapRateLimit <= 0 {
+		return nil, nil
+	}
+	limiter := getSwapRateLimiter(pairID, isSrc, tokenCfg)
+	deadline := time.Now().Add(time.Duration(tokenCfg.SwapRateWaitSeconds) * time.Second)
+	for {
+		wait, ok := limiter.take()
+		if ok {
+			return limiter, nil
+		}
+		if time.Now().Add(wait).After(deadline) {
+			return nil, tokens.ErrRateLimited
+		}
+		time.Sleep(wait)
+	}
+}
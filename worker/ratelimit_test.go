@@ -0,0 +1,54 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+func setRateLimitTestConfig(pairID string, ratePerMinute float64, burst, waitSeconds uint64) {
+	tokens.SetTokenPairsConfig(map[string]*tokens.TokenPairConfig{
+		pairID: {
+			PairID:    pairID,
+			SrcToken:  &tokens.TokenConfig{},
+			DestToken: &tokens.TokenConfig{SwapRateLimit: ratePerMinute, SwapRateBurst: burst, SwapRateWaitSeconds: waitSeconds},
+		},
+	}, false)
+}
+
+func TestWaitSwapRateLimit(t *testing.T) {
+	pairID := "testratelimit"
+	setRateLimitTestConfig(pairID, 0.001, 2, 0)
+	defer tokens.SetTokenPairsConfig(nil, false)
+
+	for i := 0; i < 2; i++ {
+		if _, err := waitSwapRateLimit(pairID, false); err != nil {
+			t.Fatalf("swap %v within burst should be allowed: %v", i, err)
+		}
+	}
+	limiter, err := waitSwapRateLimit(pairID, false)
+	if err != tokens.ErrRateLimited {
+		t.Fatalf("swap exceeding burst: want error %v, got %v", tokens.ErrRateLimited, err)
+	}
+	if limiter != nil {
+		t.Errorf("rate limited swap should not return limiter")
+	}
+
+	// not sent swap give back its token
+	limiter = getSwapRateLimiter(pairID, false, tokens.GetTokenConfig(pairID, false))
+	limiter.giveBack()
+	if _, err = waitSwapRateLimit(pairID, false); err != nil {
+		t.Errorf("swap should be allowed after token given back: %v", err)
+	}
+
+	// reloaded config take effect
+	setRateLimitTestConfig(pairID, 6000, 2, 1)
+	if _, err = waitSwapRateLimit(pairID, false); err != nil {
+		t.Errorf("swap should be allowed with reloaded rate: %v", err)
+	}
+
+	// swaps on the other side is not limited
+	if limiter, err = waitSwapRateLimit(pairID, true); err != nil || limiter != nil {
+		t.Errorf("swap on unlimited side: got limiter %v, err %v", limiter, err)
+	}
+}
@@ -0,0 +1,196 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+var swapinRefundTaskChanMap = make(map[string]chan *refundTask) // key is dcrm address
+
+// refundTask refund is sent from the swapin dcrm address, so it is processed
+// by the swapin task routine of that address to avoid racing for nonce
+type refundTask struct {
+	args   *tokens.BuildTxArgs
+	result chan *refundResult
+}
+
+type refundResult struct {
+	refundTx string
+	signed   bool // refund tx is signed (and may be sent even if err is not nil)
+	err      error
+}
+
+// RefundSwapout refund swapout whose swap tx is reverted on source chain
+// by minting the burned value back to the swapout sender on destination chain.
+// it is only called by admin (explicit operator approval is required)
+func RefundSwapout(txid, pairID, bind string) (refundTx string, err error) {
+	if !beginInflight() {
+		return "", errShuttingDown
+	}
+	defer endInflight()
+
+	unlock := lockSwap(false, txid, pairID, bind)
+	defer unlock()
+
+	value, err := checkSwapoutRefundable(txid, pairID, bind, false)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := tokens.DstBridge.(tokens.RefundBuilder); !ok {
+		return "", errors.New("refund is not supported")
+	}
+	tokenCfg := tokens.DstBridge.GetTokenConfig(pairID)
+	if tokenCfg == nil {
+		return "", tokens.ErrUnknownPairID
+	}
+	refundChan, exist := swapinRefundTaskChanMap[strings.ToLower(tokenCfg.DcrmAddress)]
+	if !exist {
+		return "", fmt.Errorf("no refund task channel for dcrm address '%v'", tokenCfg.DcrmAddress)
+	}
+
+	// mark refunding before signing, so that concurrent refunds of one swap fail
+	err = mongodb.CompareAndSwapSwapResultStatus(false, txid, pairID, bind, mongodb.MatchTxFailed, mongodb.SwapRefunding, now(), "")
+	if err != nil {
+		if err == mongodb.ErrItemNotFound {
+			return "", errors.New("swap result status is changed, can not refund")
+		}
+		return "", err
+	}
+
+	args := &tokens.BuildTxArgs{
+		SwapInfo: tokens.SwapInfo{
+			PairID:   pairID,
+			SwapID:   txid,
+			SwapType: tokens.NoSwapType,
+			TxType:   tokens.RefundSwapoutTx,
+			Bind:     bind,
+		},
+		From:        tokenCfg.DcrmAddress,
+		OriginValue: value,
+	}
+	args.EnsureTraceID()
+	task := &refundTask{args: args, result: make(chan *refundResult, 1)}
+	refundChan <- task
+	res := <-task.result
+
+	switch {
+	case res.err == nil:
+		err = mongodb.UpdateSwapResultStatus(false, txid, pairID, bind, mongodb.SwapRefunded, now(), "refund tx "+res.refundTx)
+		if err != nil {
+			logWorkerError("refund", "update swap result status failed", err, "txid", txid, "pairID", pairID, "bind", bind, "refundTx", res.refundTx)
+		}
+		return res.refundTx, nil
+	case res.signed:
+		// refund tx may be sent, keep refunding status for manual check
+		memo := fmt.Sprintf("refund tx %v send failed: %v", res.refundTx, res.err)
+		_ = mongodb.UpdateSwapResultStatus(false, txid, pairID, bind, mongodb.SwapRefunding, now(), memo)
+		return res.refundTx, res.err
+	default:
+		errc := mongodb.CompareAndSwapSwapResultStatus(false, txid, pairID, bind, mongodb.SwapRefunding, mongodb.MatchTxFailed, now(), "")
+		if errc != nil {
+			logWorkerError("refund", "revert refunding status failed", errc, "txid", txid, "pairID", pairID, "bind", bind)
+		}
+		return "", res.err
+	}
+}
+
+// doRefund build, sign and send refund tx (in swapin task routine)
+func doRefund(args *tokens.BuildTxArgs) *refundResult {
+	txid, pairID, bind := args.SwapID, args.PairID, args.Bind
+	refundBuilder := tokens.DstBridge.(tokens.RefundBuilder)
+	rawTx, err := refundBuilder.BuildRefundTx(args)
+	if err != nil {
+		return &refundResult{err: err}
+	}
+
+	var signedTx interface{}
+	var refundTx string
+	tokenCfg := tokens.DstBridge.GetTokenConfig(pairID)
+	if tokenCfg.GetDcrmAddressPrivateKey() != nil || tokenCfg.IsKmsSign() {
+		signedTx, refundTx, err = tokens.DstBridge.SignTransaction(rawTx, pairID)
+	} else {
+		signedTx, refundTx, err = dcrmSignTransaction(tokens.DstBridge, rawTx, args.GetExtraArgs())
+	}
+	if err != nil {
+		return &refundResult{err: err}
+	}
+	trackUnsentSignedTx(tokens.DstBridge, signedTx, args, refundTx, args.From)
+	defer untrackUnsentSignedTx(refundTx)
+
+	_, err = tokens.DstBridge.SendTransaction(signedTx)
+	if err != nil {
+		return &refundResult{refundTx: refundTx, signed: true, err: err}
+	}
+	if nonceSetter, ok := tokens.DstBridge.(tokens.NonceSetter); ok && isSentFromDcrmAddress(tokens.DstBridge, args) {
+		nonceSetter.IncreaseNonce(pairID, 1)
+	}
+	logWorker("refund", "refund swapout success", "txid", txid, "pairID", pairID, "bind", bind, "value", args.OriginValue, "refundTx", refundTx, "traceID", args.TraceID)
+	publishEvent(&Event{
+		Type:    EventSwapRefunded,
+		TxID:    txid,
+		PairID:  pairID,
+		Bind:    bind,
+		SwapTx:  refundTx,
+		TraceID: args.TraceID,
+	})
+	return &refundResult{refundTx: refundTx, signed: true}
+}
+
+// checkSwapoutRefundable check swap result is failed (or refunding if 'allowRefunding')
+// and its swap tx is reverted, return the swapout value to refund
+func checkSwapoutRefundable(txid, pairID, bind string, allowRefunding bool) (*big.Int, error) {
+	res, err := mongodb.FindSwapResult(false, txid, pairID, bind)
+	if err != nil {
+		return nil, err
+	}
+	if res.Status != mongodb.MatchTxFailed && !(allowRefunding && res.Status == mongodb.SwapRefunding) {
+		return nil, fmt.Errorf("swap result status is %v, can not refund", res.Status.String())
+	}
+	if res.SwapTx == "" {
+		return nil, errors.New("swap without swaptx")
+	}
+	refundBuilder, ok := tokens.SrcBridge.(tokens.RefundBuilder)
+	if !ok {
+		return nil, errors.New("refund is not supported")
+	}
+	reverted, err := refundBuilder.IsTxReverted(res.SwapTx)
+	if err != nil {
+		return nil, err
+	}
+	if !reverted {
+		return nil, tokens.ErrSwapTxNotReverted
+	}
+	value, ok := new(big.Int).SetString(res.Value, 0)
+	if !ok || value.Sign() <= 0 {
+		return nil, fmt.Errorf("wrong swap value '%v'", res.Value)
+	}
+	return value, nil
+}
+
+func rebuildAndVerifyRefund(msgHash []string, args *tokens.BuildTxArgs) error {
+	// the initiator marks refunding before signing
+	value, err := checkSwapoutRefundable(args.SwapID, args.PairID, args.Bind, true)
+	if err != nil {
+		return err
+	}
+	refundBuilder, ok := tokens.DstBridge.(tokens.RefundBuilder)
+	if !ok {
+		return errors.New("refund is not supported")
+	}
+	buildTxArgs := &tokens.BuildTxArgs{
+		SwapInfo:    args.SwapInfo,
+		From:        args.From,
+		OriginValue: value,
+		Extra:       args.Extra,
+	}
+	rawTx, err := refundBuilder.BuildRefundTx(buildTxArgs)
+	if err != nil {
+		return err
+	}
+	return tokens.DstBridge.VerifyMsgHash(rawTx, msgHash)
+}
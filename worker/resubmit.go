@@ -0,0 +1,146 @@
+package worker
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+var (
+	resubmitStarter sync.Once
+
+	maxResubmitLifetime       = int64(7 * 24 * 3600)
+	restIntervalInResubmitJob = 60 * time.Second
+)
+
+// StartResubmitJob resubmit persisted signed txs until confirmed or superseded
+func StartResubmitJob() {
+	resubmitStarter.Do(func() {
+		logWorker("resubmit", "start resubmit signed tx job")
+		for {
+			res, err := mongodb.FindSignedTxs()
+			if err != nil {
+				logWorkerError("resubmit", "find signed txs error", err)
+			}
+			for _, signedTx := range res {
+				err = processResubmit(signedTx)
+				if err != nil {
+					logWorkerError("resubmit", "process resubmit error", err, "key", signedTx.Key, "swaptx", signedTx.SwapTx)
+				}
+			}
+			restInJob(restIntervalInResubmitJob)
+		}
+	})
+}
+
+func addSignedTx(bridge tokens.CrossChainBridge, signedTx interface{}, args *tokens.BuildTxArgs, txHash, from string) {
+	rawTxSender, ok := bridge.(tokens.RawTxSender)
+	if !ok {
+		return
+	}
+	rawTx, err := rawTxSender.EncodeSignedTransaction(signedTx)
+	if err != nil {
+		logWorkerError("resubmit", "encode signed tx failed", err, "txid", args.SwapID, "swaptx", txHash)
+		return
+	}
+	err = mongodb.AddSignedTx(&mongodb.MgoSignedTx{
+		PairID:    args.PairID,
+		TxID:      args.SwapID,
+		Bind:      args.Bind,
+		SwapType:  uint32(args.SwapType),
+		SwapTx:    txHash,
+		SwapNonce: args.GetTxNonce(),
+		From:      from,
+		RawTx:     rawTx,
+		Timestamp: now(),
+		Deadline:  getDeadlineUnix(args.Deadline),
+		TxType:    uint32(args.TxType),
+	})
+	if err != nil && err != mongodb.ErrItemIsDup {
+		logWorkerError("resubmit", "add signed tx failed", err, "txid", args.SwapID, "swaptx", txHash)
+	}
+}
+
+func getDeadlineUnix(deadline time.Time) int64 {
+	if deadline.IsZero() {
+		return 0
+	}
+	return deadline.Unix()
+}
+
+// getResubmitBridge get the bridge which the signed tx is sent on
+func getResubmitBridge(signedTx *mongodb.MgoSignedTx) tokens.CrossChainBridge {
+	if tokens.SwapTxType(signedTx.TxType) == tokens.RefundSwapoutTx {
+		return tokens.DstBridge // refund is minted on destination chain
+	}
+	isSwapin := tokens.SwapType(signedTx.SwapType) == tokens.SwapinType
+	return tokens.GetCrossChainBridge(!isSwapin)
+}
+
+// isSignedTxObsolete return true if the swap is failed or reswapped with another tx,
+// resubmitting the signed tx in these cases may cause double swapping
+func isSignedTxObsolete(signedTx *mongodb.MgoSignedTx, res *mongodb.MgoSwapResult) bool {
+	if tokens.SwapTxType(signedTx.TxType) == tokens.RefundSwapoutTx {
+		return res.Status != mongodb.SwapRefunding && res.Status != mongodb.SwapRefunded
+	}
+	switch res.Status {
+	case mongodb.TxSwapFailed, mongodb.MatchTxEmpty:
+		return true
+	}
+	return !strings.EqualFold(res.SwapTx, signedTx.SwapTx)
+}
+
+func processResubmit(signedTx *mongodb.MgoSignedTx) error {
+	resBridge := getResubmitBridge(signedTx)
+
+	isSwapin := tokens.SwapType(signedTx.SwapType) == tokens.SwapinType
+	res, err := mongodb.FindSwapResult(isSwapin, signedTx.TxID, signedTx.PairID, signedTx.Bind)
+	if err != nil && err != mongodb.ErrItemNotFound {
+		return err
+	}
+	if err == mongodb.ErrItemNotFound || isSignedTxObsolete(signedTx, res) {
+		logWorkerWarn("resubmit", "signed tx is obsolete", "swaptx", signedTx.SwapTx, "txid", signedTx.TxID, "pairID", signedTx.PairID, "bind", signedTx.Bind)
+		return mongodb.RemoveSignedTx(signedTx.Key)
+	}
+
+	txStatus := resBridge.GetTransactionStatus(signedTx.SwapTx)
+	if txStatus != nil && txStatus.BlockHeight > 0 {
+		logWorker("resubmit", "signed tx is confirmed", "swaptx", signedTx.SwapTx, "height", txStatus.BlockHeight)
+		return mongodb.RemoveSignedTx(signedTx.Key)
+	}
+
+	if signedTx.Deadline > 0 && now() > signedTx.Deadline {
+		logWorkerWarn("resubmit", "signed tx passed deadline", "swaptx", signedTx.SwapTx, "deadline", signedTx.Deadline)
+		return mongodb.RemoveSignedTx(signedTx.Key)
+	}
+
+	if signedTx.Timestamp < getSepTimeInFind(maxResubmitLifetime) {
+		logWorkerWarn("resubmit", "signed tx is expired", "swaptx", signedTx.SwapTx, "timestamp", signedTx.Timestamp)
+		return mongodb.RemoveSignedTx(signedTx.Key)
+	}
+
+	if nonceSetter, ok := resBridge.(tokens.NonceSetter); ok && signedTx.From != "" {
+		nonce, err := nonceSetter.GetNonce(signedTx.From, "latest")
+		if err != nil {
+			return err
+		}
+		if nonce > signedTx.SwapNonce {
+			logWorker("resubmit", "signed tx is superseded", "swaptx", signedTx.SwapTx, "nonce", signedTx.SwapNonce, "latestNonce", nonce)
+			return mongodb.RemoveSignedTx(signedTx.Key)
+		}
+	}
+
+	rawTxSender, ok := resBridge.(tokens.RawTxSender)
+	if !ok {
+		return mongodb.RemoveSignedTx(signedTx.Key)
+	}
+	_, err = rawTxSender.SendRawTransactionHex(signedTx.RawTx)
+	if err != nil {
+		return err
+	}
+	logWorker("resubmit", "resubmit signed tx success", "swaptx", signedTx.SwapTx, "nonce", signedTx.SwapNonce)
+	return nil
+}
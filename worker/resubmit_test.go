@@ -0,0 +1,54 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/eth"
+)
+
+func TestGetResubmitBridge(t *testing.T) {
+	srcBridge, dstBridge := eth.NewCrossChainBridge(true), eth.NewCrossChainBridge(false)
+	oldSrc, oldDst := tokens.SrcBridge, tokens.DstBridge
+	tokens.SrcBridge, tokens.DstBridge = srcBridge, dstBridge
+	defer func() { tokens.SrcBridge, tokens.DstBridge = oldSrc, oldDst }()
+
+	cases := []struct {
+		name     string
+		signedTx *mongodb.MgoSignedTx
+		want     tokens.CrossChainBridge
+	}{
+		{"swapin", &mongodb.MgoSignedTx{SwapType: uint32(tokens.SwapinType)}, dstBridge},
+		{"swapout", &mongodb.MgoSignedTx{SwapType: uint32(tokens.SwapoutType)}, srcBridge},
+		{"refund", &mongodb.MgoSignedTx{SwapType: uint32(tokens.NoSwapType), TxType: uint32(tokens.RefundSwapoutTx)}, dstBridge},
+	}
+	for _, c := range cases {
+		if got := getResubmitBridge(c.signedTx); got != c.want {
+			t.Errorf("resubmit %v signed tx on wrong bridge", c.name)
+		}
+	}
+}
+
+func TestIsSignedTxObsolete(t *testing.T) {
+	swapTx := &mongodb.MgoSignedTx{SwapType: uint32(tokens.SwapinType), SwapTx: "0xaa"}
+	refundTx := &mongodb.MgoSignedTx{SwapType: uint32(tokens.NoSwapType), TxType: uint32(tokens.RefundSwapoutTx), SwapTx: "0xbb"}
+	cases := []struct {
+		name     string
+		signedTx *mongodb.MgoSignedTx
+		res      *mongodb.MgoSwapResult
+		want     bool
+	}{
+		{"pending swap", swapTx, &mongodb.MgoSwapResult{Status: mongodb.MatchTxNotStable, SwapTx: "0xAA"}, false},
+		{"failed swap", swapTx, &mongodb.MgoSwapResult{Status: mongodb.TxSwapFailed, SwapTx: "0xaa"}, true},
+		{"reswapping", swapTx, &mongodb.MgoSwapResult{Status: mongodb.MatchTxEmpty, SwapTx: "0xaa"}, true},
+		{"reswapped", swapTx, &mongodb.MgoSwapResult{Status: mongodb.MatchTxNotStable, SwapTx: "0xcc"}, true},
+		{"refunding", refundTx, &mongodb.MgoSwapResult{Status: mongodb.SwapRefunding, SwapTx: "0xaa"}, false},
+		{"refund reverted", refundTx, &mongodb.MgoSwapResult{Status: mongodb.MatchTxFailed, SwapTx: "0xaa"}, true},
+	}
+	for _, c := range cases {
+		if got := isSignedTxObsolete(c.signedTx, c.res); got != c.want {
+			t.Errorf("%v: want obsolete %v, got %v", c.name, c.want, got)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package worker
+
+import (
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+var restIntervalInSendStatsJob = 60 * time.Second
+
+// StartSendStatsJob periodically log count of in-flight and queued sending txs
+func StartSendStatsJob() {
+	logWorker("sendstats", "start send stats job")
+	for {
+		inFlight, queued := tokens.GetSendConcurrency()
+		if inFlight > 0 || queued > 0 {
+			logWorker("sendstats", "sending txs", "inFlight", inFlight, "queued", queued)
+		}
+		restInJob(restIntervalInSendStatsJob)
+	}
+}
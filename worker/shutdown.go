@@ -0,0 +1,96 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+var (
+	errShuttingDown = errors.New("worker is shutting down")
+
+	shuttingDown int32
+	inflightWG   sync.WaitGroup
+	inflightLock sync.Mutex
+
+	// signed but not yet sent txs, key is tx hash
+	unsentSignedTxs     = make(map[string]*unsentSignedTx)
+	unsentSignedTxsLock sync.Mutex
+)
+
+type unsentSignedTx struct {
+	bridge   tokens.CrossChainBridge
+	signedTx interface{}
+	args     *tokens.BuildTxArgs
+	from     string
+}
+
+func isShuttingDown() bool {
+	return atomic.LoadInt32(&shuttingDown) != 0
+}
+
+// beginInflight register an in-flight build/send, return false if shutting down
+func beginInflight() bool {
+	inflightLock.Lock()
+	defer inflightLock.Unlock()
+	if isShuttingDown() {
+		return false
+	}
+	inflightWG.Add(1)
+	return true
+}
+
+func endInflight() {
+	inflightWG.Done()
+}
+
+func trackUnsentSignedTx(bridge tokens.CrossChainBridge, signedTx interface{}, args *tokens.BuildTxArgs, txHash, from string) {
+	unsentSignedTxsLock.Lock()
+	defer unsentSignedTxsLock.Unlock()
+	unsentSignedTxs[txHash] = &unsentSignedTx{
+		bridge:   bridge,
+		signedTx: signedTx,
+		args:     args,
+		from:     from,
+	}
+}
+
+func untrackUnsentSignedTx(txHash string) {
+	unsentSignedTxsLock.Lock()
+	defer unsentSignedTxsLock.Unlock()
+	delete(unsentSignedTxs, txHash)
+}
+
+// Shutdown stop accepting new builds, wait in-flight builds and sends to complete
+// or ctx to expire, then persist signed but unsent txs to the resubmission queue
+func Shutdown(ctx context.Context) (err error) {
+	inflightLock.Lock()
+	atomic.StoreInt32(&shuttingDown, 1)
+	inflightLock.Unlock()
+	logWorker("shutdown", "stop accepting new builds, wait in-flight builds")
+
+	done := make(chan struct{})
+	go func() {
+		inflightWG.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		logWorker("shutdown", "all in-flight builds completed")
+	case <-ctx.Done():
+		err = ctx.Err()
+		logWorkerWarn("shutdown", "wait in-flight builds timeout", "err", err)
+	}
+
+	unsentSignedTxsLock.Lock()
+	defer unsentSignedTxsLock.Unlock()
+	for txHash, unsent := range unsentSignedTxs {
+		logWorker("shutdown", "persist signed unsent tx", "txid", unsent.args.SwapID, "swaptx", txHash)
+		addSignedTx(unsent.bridge, unsent.signedTx, unsent.args, txHash, unsent.from)
+		delete(unsentSignedTxs, txHash)
+	}
+	return err
+}
@@ -0,0 +1,57 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/dcrm"
+	"github.com/anyswap/CrossChain-Bridge/rpc/client"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+const maxDcrmSignRetryInterval = 1 * time.Minute
+
+// dcrmSigner the dcrm signing part of bridge
+type dcrmSigner interface {
+	DcrmSignTransaction(rawTx interface{}, args *tokens.BuildTxArgs) (signedTx interface{}, txHash string, err error)
+}
+
+// isTransientSignError dcrm sign status timeout, failure or still unavailable
+// after retries, failed dcrm rpc requests and transient rpc errors are worth retrying,
+// others (eg. verify tx failed) are permanent
+func isTransientSignError(err error) bool {
+	switch {
+	case errors.Is(err, dcrm.ErrGetSignStatusTimeout),
+		errors.Is(err, dcrm.ErrGetSignStatusFailed),
+		errors.Is(err, dcrm.ErrGetSignStatusExhausted),
+		errors.Is(err, dcrm.ErrRPCPostFailed):
+		return true
+	}
+	return client.IsTransientError(err)
+}
+
+// dcrmSignWithRetry try dcrm signing at most 'maxTry' times and double the
+// interval after each retry. return ErrSigningFailed wrapping the last error if all failed or permanent error
+func dcrmSignWithRetry(signer dcrmSigner, rawTx interface{}, args *tokens.BuildTxArgs, maxTry int, interval time.Duration) (signedTx interface{}, txHash string, err error) {
+	for i := 0; i < maxTry; i++ {
+		if i > 0 {
+			time.Sleep(interval)
+			interval *= 2
+			if interval > maxDcrmSignRetryInterval {
+				interval = maxDcrmSignRetryInterval
+			}
+		}
+		signedTx, txHash, err = signer.DcrmSignTransaction(rawTx, args)
+		if err == nil {
+			return signedTx, txHash, nil
+		}
+		if !isTransientSignError(err) {
+			logWorkerError("sign", "dcrm sign failed with permanent error", err, "txid", args.SwapID, "pairID", args.PairID)
+			return nil, "", fmt.Errorf("%w: %v", tokens.ErrSigningFailed, err)
+		}
+		logWorkerWarn("sign", "dcrm sign failed with transient error", "txid", args.SwapID, "pairID", args.PairID, "times", i+1, "err", err)
+	}
+	logWorkerError("sign", "dcrm sign failed after retries", err, "txid", args.SwapID, "pairID", args.PairID, "times", maxTry)
+	return nil, "", fmt.Errorf("%w: %v", tokens.ErrSigningFailed, err)
+}
@@ -0,0 +1,67 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/dcrm"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+// mockDcrmSigner fail with 'errs' in order, then succeed
+type mockDcrmSigner struct {
+	errs  []error
+	calls int
+}
+
+func (s *mockDcrmSigner) DcrmSignTransaction(rawTx interface{}, args *tokens.BuildTxArgs) (signedTx interface{}, txHash string, err error) {
+	s.calls++
+	if s.calls <= len(s.errs) {
+		return nil, "", s.errs[s.calls-1]
+	}
+	return rawTx, "0xsigned", nil
+}
+
+func TestDcrmSignWithRetry(t *testing.T) {
+	args := &tokens.BuildTxArgs{}
+	args.SwapID = "0xswap"
+	args.PairID = "testpair"
+	permanentErr := errors.New("[sign] verify tx receiver failed")
+
+	cases := []struct {
+		name      string
+		errs      []error
+		maxTry    int
+		wantErr   error
+		wantCalls int
+	}{
+		{"success at once", nil, 3, nil, 1},
+		{"fail then succeed", []error{dcrm.ErrGetSignStatusTimeout, dcrm.ErrGetSignStatusFailed}, 3, nil, 3},
+		{"transient rpc error", []error{errors.New("dial tcp: connection refused")}, 3, nil, 2},
+		{"sign status exhausted", []error{dcrm.ErrGetSignStatusExhausted}, 3, nil, 2},
+		{"dcrm rpc post failed", []error{fmt.Errorf("%w: [post] dcrm_sign error, EOF", dcrm.ErrRPCPostFailed)}, 3, nil, 2},
+		{"retries exhausted", []error{dcrm.ErrGetSignStatusTimeout, dcrm.ErrGetSignStatusTimeout}, 2, tokens.ErrSigningFailed, 2},
+		{"permanent error", []error{permanentErr}, 3, tokens.ErrSigningFailed, 1},
+	}
+	for _, c := range cases {
+		signer := &mockDcrmSigner{errs: c.errs}
+		signedTx, txHash, err := dcrmSignWithRetry(signer, "rawtx", args, c.maxTry, time.Millisecond)
+		if !errors.Is(err, c.wantErr) || (c.wantErr == nil) != (err == nil) {
+			t.Errorf("%v: want error %v, got %v", c.name, c.wantErr, err)
+		}
+		if signer.calls != c.wantCalls {
+			t.Errorf("%v: want %v calls, got %v", c.name, c.wantCalls, signer.calls)
+		}
+		if c.wantErr == nil && (signedTx != "rawtx" || txHash != "0xsigned") {
+			t.Errorf("%v: wrong sign result %v %v", c.name, signedTx, txHash)
+		}
+	}
+
+	_, _, err := dcrmSignWithRetry(&mockDcrmSigner{errs: []error{permanentErr}}, "rawtx", args, 3, time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), permanentErr.Error()) {
+		t.Errorf("signing failed error should keep the cause, got %v", err)
+	}
+}
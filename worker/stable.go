@@ -1,8 +1,11 @@
 package worker
 
 import (
+	"errors"
+	"math/big"
 	"sync"
 
+	"github.com/anyswap/CrossChain-Bridge/common"
 	"github.com/anyswap/CrossChain-Bridge/mongodb"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
 	"github.com/anyswap/CrossChain-Bridge/types"
@@ -15,6 +18,7 @@ var (
 
 // StartStableJob stable job
 func StartStableJob() {
+	loadFeesSpent()
 	go startSwapinStableJob()
 	go startSwapoutStableJob()
 }
@@ -107,6 +111,18 @@ func processSwapStable(swap *mongodb.MgoSwapResult, isSwapin bool) (err error) {
 			if !txFailed && token != nil && token.ContractAddress != "" && len(receipt.Logs) == 0 {
 				txFailed = true
 			}
+			if !txFailed && isSwapin {
+				err = verifySwapinResult(resBridge, swap)
+				if errors.Is(err, tokens.ErrTxNotStable) {
+					return nil // retry in next loop
+				}
+				if err != nil && !errors.Is(err, tokens.ErrSwapinResultMismatch) {
+					return err
+				}
+				txFailed = err != nil
+			}
+			recordFeesSpent(resBridge, swap, isSwapin, txStatus)
+			recordSwapTxResult(swap.SwapTx, txFailed)
 			if txFailed {
 				return markSwapResultFailed(swap.TxID, swap.PairID, swap.Bind, isSwapin)
 			}
@@ -121,3 +137,64 @@ func processSwapStable(swap *mongodb.MgoSwapResult, isSwapin bool) (err error) {
 	}
 	return updateSwapResult(swap.TxID, swap.PairID, swap.Bind, matchTx)
 }
+
+func verifySwapinResult(resBridge tokens.CrossChainBridge, swap *mongodb.MgoSwapResult) error {
+	verifier, ok := resBridge.(tokens.SwapinResultVerifier)
+	if !ok {
+		return nil
+	}
+	var expectedAmount *big.Int
+	if swapValue, ok := new(big.Int).SetString(swap.SwapValue, 0); ok {
+		expectedAmount = swapValue
+	}
+	var expectedAccount string
+	if common.IsHexAddress(swap.Bind) { // bind may be a resolved name
+		expectedAccount = swap.Bind
+	}
+	_, err := verifier.VerifySwapinResult(swap.SwapTx, swap.PairID, expectedAccount, expectedAmount)
+	if err != nil && !errors.Is(err, tokens.ErrTxNotStable) {
+		logWorkerError("stable", "verify swapin result failed", err, "txid", swap.TxID, "swaptxid", swap.SwapTx, "bind", swap.Bind, "swapValue", swap.SwapValue)
+	}
+	return err
+}
+
+func recordFeesSpent(resBridge tokens.CrossChainBridge, swap *mongodb.MgoSwapResult, isSwapin bool, txStatus *tokens.TxStatus) {
+	feeCalculator, ok := resBridge.(tokens.TxFeeCalculator)
+	if !ok {
+		return
+	}
+	fee, err := feeCalculator.CalcTxFee(swap.SwapTx, txStatus.Receipt)
+	if err != nil {
+		logWorkerError("stable", "calc tx fee failed", err, "swaptxid", swap.SwapTx)
+		return
+	}
+	// swap may be processed again if marking result failed, record fee only once
+	err = mongodb.MarkSwapFeeRecorded(isSwapin, swap.TxID, swap.PairID, swap.Bind, swap.SwapTx)
+	if err != nil {
+		if err != mongodb.ErrItemNotFound {
+			logWorkerError("stable", "mark tx fee recorded failed", err, "swaptxid", swap.SwapTx, "fee", fee)
+		}
+		return
+	}
+	tokens.AddFeesSpent(swap.PairID, !isSwapin, fee)
+	err = mongodb.AddSwapGasFee(swap.PairID, fee, isSwapin)
+	if err != nil {
+		logWorkerError("stable", "persist tx fee failed", err, "swaptxid", swap.SwapTx, "fee", fee)
+	}
+}
+
+// restore fees spent from database, so restarts don't reset the tally
+func loadFeesSpent() {
+	for _, pairID := range tokens.GetAllPairIDs() {
+		stat, err := mongodb.FindSwapStatistics(pairID)
+		if err != nil {
+			continue
+		}
+		if fee, ok := new(big.Int).SetString(stat.TotalSwapinGasFee, 0); ok {
+			tokens.SetFeesSpent(pairID, false, fee)
+		}
+		if fee, ok := new(big.Int).SetString(stat.TotalSwapoutGasFee, 0); ok {
+			tokens.SetFeesSpent(pairID, true, fee)
+		}
+	}
+}
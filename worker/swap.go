@@ -7,6 +7,7 @@ import (
 	"math/big"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/anyswap/CrossChain-Bridge/common"
 	"github.com/anyswap/CrossChain-Bridge/mongodb"
@@ -27,6 +28,7 @@ var (
 
 // StartSwapJob swap job
 func StartSwapJob() {
+	initSwapOrderingPolicy()
 	for _, pairCfg := range tokens.GetTokenPairsConfig() {
 		AddSwapJob(pairCfg)
 	}
@@ -38,12 +40,14 @@ func AddSwapJob(pairCfg *tokens.TokenPairConfig) {
 	swapinDcrmAddr := strings.ToLower(pairCfg.DestToken.DcrmAddress)
 	if _, exist := swapinTaskChanMap[swapinDcrmAddr]; !exist {
 		swapinTaskChanMap[swapinDcrmAddr] = make(chan *tokens.BuildTxArgs, swapChanSize)
-		go processSwapTask(swapinTaskChanMap[swapinDcrmAddr])
+		swapinRefundTaskChanMap[swapinDcrmAddr] = make(chan *refundTask)
+		go processSwapTask(swapinTaskChanMap[swapinDcrmAddr], nil, swapinRefundTaskChanMap[swapinDcrmAddr])
 	}
 	swapoutDcrmAddr := strings.ToLower(pairCfg.SrcToken.DcrmAddress)
 	if _, exist := swapoutTaskChanMap[swapoutDcrmAddr]; !exist {
 		swapoutTaskChanMap[swapoutDcrmAddr] = make(chan *tokens.BuildTxArgs, swapChanSize)
-		go processSwapTask(swapoutTaskChanMap[swapoutDcrmAddr])
+		swapoutBatchTaskChanMap[swapoutDcrmAddr] = make(chan []*tokens.BuildTxArgs, swapChanSize)
+		go processSwapTask(swapoutTaskChanMap[swapoutDcrmAddr], swapoutBatchTaskChanMap[swapoutDcrmAddr], nil)
 	}
 
 	go startSwapinSwapJob(pairID)
@@ -59,6 +63,7 @@ func startSwapinSwapJob(pairID string) {
 		}
 		if len(res) > 0 {
 			logWorker("swapin", "find swapins to swap", "count", len(res))
+			res = swapOrderingPolicy(res, true)
 		}
 		for _, swap := range res {
 			err = processSwapinSwap(swap)
@@ -81,6 +86,7 @@ func startSwapoutSwapJob(pairID string) {
 		}
 		if len(res) > 0 {
 			logWorker("swapout", "find swapouts to swap", "count", len(res))
+			res = swapOrderingPolicy(res, false)
 		}
 		for _, swap := range res {
 			err = processSwapoutSwap(swap)
@@ -182,6 +188,9 @@ func processSwap(swap *mongodb.MgoSwap, isSwapin bool) (err error) {
 		From:        toTokenCfg.DcrmAddress,
 		OriginValue: value,
 	}
+	if lifetime := toTokenCfg.SwapTxLifetime; lifetime > 0 {
+		args.Deadline = time.Now().Add(time.Duration(lifetime) * time.Second)
+	}
 
 	return dispatchSwapTask(args)
 }
@@ -246,6 +255,7 @@ func processHistory(res *mongodb.MgoSwapResult, isSwapin bool) error {
 }
 
 func dispatchSwapTask(args *tokens.BuildTxArgs) error {
+	args.EnsureTraceID()
 	from := strings.ToLower(args.From)
 	switch args.SwapType {
 	case tokens.SwapinType:
@@ -259,22 +269,52 @@ func dispatchSwapTask(args *tokens.BuildTxArgs) error {
 		if !exist {
 			return fmt.Errorf("no swapout task channel for dcrm address '%v'", args.From)
 		}
+		if isBatchSwapout(args) {
+			addBatchSwapout(args)
+			return nil
+		}
 		swapChan <- args
 	default:
 		return fmt.Errorf("wrong swap type '%v'", args.SwapType.String())
 	}
-	logWorker("doSwap", "dispatch swap task", "pairID", args.PairID, "txid", args.SwapID, "bind", args.Bind, "swapType", args.SwapType.String(), "value", args.OriginValue)
+	logWorker("doSwap", "dispatch swap task", "pairID", args.PairID, "txid", args.SwapID, "bind", args.Bind, "swapType", args.SwapType.String(), "value", args.OriginValue, "traceID", args.TraceID)
 	return nil
 }
 
-func processSwapTask(swapChan <-chan *tokens.BuildTxArgs) {
+// process swap tasks of one dcrm address sequentially (batch and refund channels may be nil),
+// so that txs sent from the same address never race for one nonce
+func processSwapTask(swapChan <-chan *tokens.BuildTxArgs, batchChan <-chan []*tokens.BuildTxArgs, refundChan <-chan *refundTask) {
 	for {
-		args := <-swapChan
-		err := doSwap(args)
-		switch err {
-		case nil, errAlreadySwapped:
-		default:
-			logWorkerError("doSwap", "process failed", err, "pairID", args.PairID, "txid", args.SwapID, "swapType", args.SwapType.String(), "value", args.OriginValue)
+		select {
+		case args := <-swapChan:
+			if !beginInflight() {
+				logWorkerWarn("doSwap", "ignore swap task when shutting down", "pairID", args.PairID, "txid", args.SwapID, "bind", args.Bind)
+				continue
+			}
+			err := doSwap(args)
+			endInflight()
+			switch err {
+			case nil, errAlreadySwapped:
+			default:
+				logWorkerError("doSwap", "process failed", err, "pairID", args.PairID, "txid", args.SwapID, "swapType", args.SwapType.String(), "value", args.OriginValue)
+			}
+		case batch := <-batchChan:
+			if !beginInflight() {
+				logWorkerWarn("doSwap", "ignore batch swap task when shutting down", "size", len(batch))
+				continue
+			}
+			err := doBatchSwapout(batch)
+			endInflight()
+			if err != nil {
+				logWorkerError("doSwap", "process batch failed", err, "size", len(batch))
+			}
+		case task := <-refundChan:
+			if !beginInflight() {
+				task.result <- &refundResult{err: errShuttingDown}
+				continue
+			}
+			task.result <- doRefund(task.args)
+			endInflight()
 		}
 	}
 }
@@ -289,6 +329,9 @@ func doSwap(args *tokens.BuildTxArgs) (err error) {
 	isSwapin := swapType == tokens.SwapinType
 	resBridge := tokens.GetCrossChainBridge(!isSwapin)
 
+	unlock := lockSwap(isSwapin, txid, pairID, bind)
+	defer unlock()
+
 	res, err := mongodb.FindSwapResult(isSwapin, txid, pairID, bind)
 	if err != nil {
 		return err
@@ -298,26 +341,47 @@ func doSwap(args *tokens.BuildTxArgs) (err error) {
 		return err
 	}
 
-	logWorker("doSwap", "start to process", "pairID", pairID, "txid", txid, "bind", bind, "isSwapin", isSwapin, "value", originValue)
+	logWorker("doSwap", "start to process", "pairID", pairID, "txid", txid, "bind", bind, "isSwapin", isSwapin, "value", originValue, "traceID", args.EnsureTraceID())
+
+	if isSwapin {
+		err = checkSwapinConfirmations(pairID, txid, originValue)
+		if err != nil {
+			return err
+		}
+	}
+
+	limiter, err := waitSwapRateLimit(pairID, !isSwapin)
+	if err != nil {
+		logWorkerWarn("doSwap", "swap is rate limited", "txid", txid, "bind", bind, "isSwapin", isSwapin, "traceID", args.TraceID)
+		return err
+	}
+	sent := false
+	defer func() {
+		if limiter != nil && !sent {
+			limiter.giveBack()
+		}
+	}()
 
 	rawTx, err := resBridge.BuildRawTransaction(args)
 	if err != nil {
-		logWorkerError("doSwap", "build tx failed", err, "txid", txid, "bind", bind, "isSwapin", isSwapin)
+		logWorkerError("doSwap", "build tx failed", err, "txid", txid, "bind", bind, "isSwapin", isSwapin, "traceID", args.TraceID)
 		return err
 	}
 
 	var signedTx interface{}
 	var txHash string
 	tokenCfg := resBridge.GetTokenConfig(pairID)
-	if tokenCfg.GetDcrmAddressPrivateKey() != nil {
+	if tokenCfg.GetDcrmAddressPrivateKey() != nil || tokenCfg.IsKmsSign() {
 		signedTx, txHash, err = resBridge.SignTransaction(rawTx, pairID)
 	} else {
 		signedTx, txHash, err = dcrmSignTransaction(resBridge, rawTx, args.GetExtraArgs())
 	}
 	if err != nil {
-		logWorkerError("doSwap", "sign tx failed", err, "txid", txid, "bind", bind, "isSwapin", isSwapin)
+		logWorkerError("doSwap", "sign tx failed", err, "txid", txid, "bind", bind, "isSwapin", isSwapin, "traceID", args.TraceID)
 		return err
 	}
+	trackUnsentSignedTx(resBridge, signedTx, args, txHash, args.From)
+	defer untrackUnsentSignedTx(txHash)
 
 	swapTxNonce := args.GetTxNonce()
 
@@ -331,17 +395,24 @@ func doSwap(args *tokens.BuildTxArgs) (err error) {
 	}
 	err = updateSwapResult(txid, pairID, bind, matchTx)
 	if err != nil {
-		logWorkerError("doSwap", "update swap result failed", err, "txid", txid, "bind", bind, "isSwapin", isSwapin)
+		logWorkerError("doSwap", "update swap result failed", err, "txid", txid, "bind", bind, "isSwapin", isSwapin, "traceID", args.TraceID)
 		return err
 	}
 
 	err = mongodb.UpdateSwapStatus(isSwapin, txid, pairID, bind, mongodb.TxProcessed, now(), "")
 	if err != nil {
-		logWorkerError("doSwap", "update swap status failed", err, "txid", txid, "bind", bind, "isSwapin", isSwapin)
+		logWorkerError("doSwap", "update swap status failed", err, "txid", txid, "bind", bind, "isSwapin", isSwapin, "traceID", args.TraceID)
 		return err
 	}
 
-	return sendSignedTransaction(resBridge, signedTx, txid, pairID, bind, isSwapin)
+	err = sendSignedTransaction(resBridge, signedTx, args)
+	if err != nil {
+		return err
+	}
+	sent = true
+	// persist for resubmitting only after sent, failed swaps must not be rebroadcasted
+	addSignedTx(resBridge, signedTx, args, txHash, args.From)
+	return nil
 }
 
 type swapInfo struct {
@@ -399,3 +470,23 @@ func getSwapHistory(txid, bind string, isSwapin bool) *swapInfo {
 
 	return nil
 }
+
+// check confirmations of large value swapin by confirmation tiers
+// (the default confirmations is already checked when verifying)
+func checkSwapinConfirmations(pairID, txid string, value *big.Int) error {
+	srcBridge := tokens.SrcBridge
+	required := tokens.GetRequiredConfirmations(pairID, value, true)
+	if chainCfg := srcBridge.GetChainConfig(); chainCfg.Confirmations != nil && required <= *chainCfg.Confirmations {
+		return nil
+	}
+	txStatus := srcBridge.GetTransactionStatus(txid)
+	if txStatus == nil || txStatus.BlockHeight == 0 || txStatus.Confirmations < required {
+		var confirmations uint64
+		if txStatus != nil {
+			confirmations = txStatus.Confirmations
+		}
+		logWorkerTrace("doSwap", "swapin confirmations not enough", "pairID", pairID, "txid", txid, "value", value, "required", required, "confirmations", confirmations)
+		return tokens.ErrTxNotStable
+	}
+	return nil
+}
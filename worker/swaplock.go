@@ -0,0 +1,48 @@
+package worker
+
+import (
+	"sync"
+
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+)
+
+var (
+	swapLocks     = make(map[string]*swapLock) // key is swap type + swap key
+	swapLocksLock sync.Mutex
+)
+
+type swapLock struct {
+	sync.Mutex
+	refs int
+}
+
+func getSwapLockKey(isSwapin bool, txid, pairID, bind string) string {
+	return getSwapType(isSwapin).String() + ":" + mongodb.GetSwapKey(txid, pairID, bind)
+}
+
+// lockSwap serialize processing of one swap (eg. swap worker and admin refund),
+// return the unlock func
+func lockSwap(isSwapin bool, txid, pairID, bind string) (unlock func()) {
+	key := getSwapLockKey(isSwapin, txid, pairID, bind)
+
+	swapLocksLock.Lock()
+	lock, exist := swapLocks[key]
+	if !exist {
+		lock = &swapLock{}
+		swapLocks[key] = lock
+	}
+	lock.refs++
+	swapLocksLock.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+
+		swapLocksLock.Lock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(swapLocks, key)
+		}
+		swapLocksLock.Unlock()
+	}
+}
@@ -0,0 +1,66 @@
+package worker
+
+import (
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/params"
+)
+
+// swap ordering policies
+const (
+	SwapOrderingFIFO  = "fifo"
+	SwapOrderingValue = "value"
+)
+
+// SwapOrderingPolicy reorder found swaps before processing them
+type SwapOrderingPolicy func(swaps []*mongodb.MgoSwap, isSwapin bool) []*mongodb.MgoSwap
+
+var swapOrderingPolicy SwapOrderingPolicy = orderSwapsByFIFO
+
+// SetSwapOrderingPolicy set policy of ordering swaps to be processed
+func SetSwapOrderingPolicy(policy SwapOrderingPolicy) {
+	if policy == nil {
+		policy = orderSwapsByFIFO
+	}
+	swapOrderingPolicy = policy
+}
+
+func initSwapOrderingPolicy() {
+	switch strings.ToLower(params.GetConfig().SwapOrderingPolicy) {
+	case SwapOrderingValue:
+		SetSwapOrderingPolicy(orderSwapsByValue)
+	default:
+		SetSwapOrderingPolicy(orderSwapsByFIFO)
+	}
+}
+
+// orderSwapsByFIFO keep the order of finding (the default)
+func orderSwapsByFIFO(swaps []*mongodb.MgoSwap, isSwapin bool) []*mongodb.MgoSwap {
+	return swaps
+}
+
+// orderSwapsByValue process swaps with larger origin value first
+func orderSwapsByValue(swaps []*mongodb.MgoSwap, isSwapin bool) []*mongodb.MgoSwap {
+	if len(swaps) < 2 {
+		return swaps
+	}
+	values := make(map[*mongodb.MgoSwap]*big.Int, len(swaps))
+	for _, swap := range swaps {
+		value := big.NewInt(0)
+		res, err := mongodb.FindSwapResult(isSwapin, swap.TxID, swap.PairID, swap.Bind)
+		if err == nil {
+			if v, errf := common.GetBigIntFromStr(res.Value); errf == nil {
+				value = v
+			}
+		}
+		values[swap] = value
+	}
+	sort.SliceStable(swaps, func(i, j int) bool {
+		return values[swaps[i]].Cmp(values[swaps[j]]) > 0
+	})
+	return swaps
+}
@@ -1,6 +1,7 @@
 package worker
 
 import (
+	"math/big"
 	"sync"
 
 	"github.com/anyswap/CrossChain-Bridge/mongodb"
@@ -135,7 +136,8 @@ func updateSwapStatus(pairID, txid, bind string, swapInfo *tokens.TxSwapInfo, is
 		return err
 	case nil:
 		status := mongodb.TxNotSwapped
-		if swapInfo.Value.Cmp(tokens.GetBigValueThreshold(pairID, isSwapin)) > 0 {
+		if swapInfo.Value.Cmp(tokens.GetBigValueThreshold(pairID, isSwapin)) > 0 ||
+			requireUSDValueReview(pairID, swapInfo.Value, isSwapin) {
 			status = mongodb.TxWithBigValue
 			resultStatus = mongodb.TxWithBigValue
 		}
@@ -171,3 +173,18 @@ func updateSwapStatus(pairID, txid, bind string, swapInfo *tokens.TxSwapInfo, is
 	}
 	return addInitialSwapResult(swapInfo, resultStatus, isSwapin)
 }
+
+// requireUSDValueReview swap value exceeds 'MaxSwapUSD' of receiving side with 'MaxSwapUSDManualReview',
+// also require review if usd value is unavailable
+func requireUSDValueReview(pairID string, value *big.Int, isSwapin bool) bool {
+	tokenCfg := tokens.GetTokenConfig(pairID, !isSwapin)
+	if tokenCfg == nil || !tokenCfg.MaxSwapUSDManualReview {
+		return false
+	}
+	exceeds, err := tokens.IsSwapExceedsUSDLimit(tokenCfg, pairID, value, isSwapin)
+	if err != nil {
+		logWorkerWarn("verify", "check swap usd value failed, require manual review", "pairID", pairID, "value", value, "err", err)
+		return true
+	}
+	return exceeds
+}
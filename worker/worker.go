@@ -22,6 +22,9 @@ func StartWork(isServer bool) {
 	go StartUpdateLatestBlockHeightJob()
 	time.Sleep(interval)
 
+	go StartChainIDCheckJob()
+	time.Sleep(interval)
+
 	if !isServer {
 		go StartAcceptSignJob()
 		time.Sleep(interval)
@@ -39,4 +42,16 @@ func StartWork(isServer bool) {
 	time.Sleep(interval)
 
 	go StartAggregateJob()
+	time.Sleep(interval)
+
+	go StartResubmitJob()
+	time.Sleep(interval)
+
+	go StartAnomalyDetectJob()
+	time.Sleep(interval)
+
+	go StartPendingTxMonitorJob()
+	time.Sleep(interval)
+
+	go StartSendStatsJob()
 }